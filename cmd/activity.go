@@ -0,0 +1,428 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/liuminhaw/yatijapp-tui/internal/activity"
+	"github.com/liuminhaw/yatijapp-tui/internal/data"
+	"github.com/liuminhaw/yatijapp-tui/internal/datetime"
+	"github.com/liuminhaw/yatijapp-tui/internal/hook"
+	"github.com/liuminhaw/yatijapp-tui/internal/style"
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	activityRows       = 12
+	activityChangeRows = 6
+	activityEventWidth = 22
+)
+
+// activityEntries turns the events into journal entries. The status change
+// of an action and the end of a session come along with the update event of
+// the same change, they replace it rather than adding an entry.
+func activityEntries(cfg config, events []hook.Event, at time.Time) []activity.Entry {
+	var entries []activity.Entry
+	updates := make(map[string]int)
+	for _, event := range events {
+		switch event.Name {
+		case hook.ActionStatusChanged, hook.SessionEnded:
+			if i, ok := updates[event.UUID]; ok {
+				entries[i].Event = event.Name
+				continue
+			}
+		case hook.ActionUpdated, hook.SessionUpdated:
+			updates[event.UUID] = len(entries)
+		}
+
+		e := activity.Entry{
+			At:         at,
+			Event:      event.Name,
+			Endpoint:   cfg.apiEndpoint,
+			RecordType: event.RecordType,
+			UUID:       event.UUID,
+			Before:     activityRecord(event.Previous),
+			After:      activityRecord(event.Record),
+		}
+		for _, r := range []any{event.Record, event.Previous} {
+			if session, ok := r.(data.Session); ok {
+				e.Title = session.ActionTitle
+				break
+			}
+			if record, ok := r.(yatijappRecord); ok {
+				e.Title = record.GetTitle()
+				break
+			}
+		}
+
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+func activityRecord(record any) json.RawMessage {
+	if record == nil {
+		return nil
+	}
+	b, err := json.Marshal(record)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// recordActivity appends the events to the activity journal.
+func recordActivity(cfg config, events []hook.Event) tea.Cmd {
+	if len(events) == 0 {
+		return nil
+	}
+
+	entries := activityEntries(cfg, events, time.Now())
+	return func() tea.Msg {
+		if err := cfg.activityLog.Append(entries...); err != nil {
+			cfg.logger.Error(err.Error(), slog.String("action", "record activity"))
+		}
+		return nil
+	}
+}
+
+// recordAccountActivity appends a sign in or sign out of the account to the
+// activity journal, it is called from the command doing the request.
+func recordAccountActivity(cfg config, event, account string) {
+	err := cfg.activityLog.Append(activity.Entry{
+		At:       time.Now(),
+		Event:    event,
+		Endpoint: cfg.apiEndpoint,
+		Title:    account,
+	})
+	if err != nil {
+		cfg.logger.Error(err.Error(), slog.String("action", "record activity"))
+	}
+}
+
+// activityChangeValue shows the times of a change in the display format.
+func activityChangeValue(value string) string {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return datetime.Display().DateTime(t)
+	}
+	return value
+}
+
+// activitySummary describes the entry in a few lines: the changed fields of
+// updates, the record of creations and deletions.
+func activitySummary(e activity.Entry) []string {
+	if e.RecordType == "" {
+		return []string{e.Title + " at " + e.Endpoint}
+	}
+
+	var lines []string
+	for _, c := range e.Changes() {
+		if c.Field == "notes" {
+			lines = append(lines, "notes: changed")
+			continue
+		}
+		lines = append(lines, fmt.Sprintf(
+			"%s: %s → %s", c.Field, activityChangeValue(c.Before), activityChangeValue(c.After),
+		))
+	}
+	if len(lines) > 0 {
+		return lines
+	}
+
+	switch {
+	case len(e.Before) == 0 && len(e.After) > 0:
+		return []string{fmt.Sprintf("%s %q created", e.RecordType, e.Title)}
+	case len(e.After) == 0:
+		if e.Title == "" {
+			return []string{e.RecordType + " " + e.UUID + " deleted"}
+		}
+		return []string{fmt.Sprintf("%s %q deleted", e.RecordType, e.Title)}
+	default:
+		return []string{"no field changed"}
+	}
+}
+
+// activityPage browses the activity journal, newest entries first.
+type activityPage struct {
+	cfg config
+
+	all     []activity.Entry
+	entries []activity.Entry
+	cursor  int
+
+	filter    Focusable
+	filtering bool
+	err       error
+
+	width  int
+	height int
+
+	prev tea.Model
+}
+
+func newActivityPage(cfg config, termSize style.ViewSize, prev tea.Model) activityPage {
+	p := activityPage{
+		cfg: cfg,
+		filter: generalInput(inputFieldConfig{
+			width:       viewWidth - 12,
+			placeholder: "since:yesterday event:session type:action words",
+		}),
+		width:  termSize.Width,
+		height: termSize.Height,
+		prev:   prev,
+	}
+	p.load()
+
+	return p
+}
+
+func (p activityPage) Init() tea.Cmd {
+	return nil
+}
+
+// load reads the journal again, keeping the filter.
+func (p *activityPage) load() {
+	all, err := p.cfg.activityLog.Entries(activity.Filter{})
+	if err != nil {
+		p.cfg.logger.Error(err.Error(), slog.String("action", "load activity"))
+		p.err = err
+		return
+	}
+	p.all = all
+	p.applyFilter()
+}
+
+func (p *activityPage) applyFilter() {
+	f, err := activity.ParseFilter(p.filter.Value(), datetime.Now())
+	p.err = err
+	if err != nil {
+		return
+	}
+
+	p.entries = nil
+	for _, e := range p.all {
+		if f.Match(e) {
+			p.entries = append(p.entries, e)
+		}
+	}
+	p.cursor = min(p.cursor, max(len(p.entries)-1, 0))
+}
+
+func (p activityPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.width = msg.Width
+		p.height = msg.Height
+	case switchToPreviousMsg:
+		p.load()
+	case tea.KeyMsg:
+		if p.filtering {
+			switch msg.String() {
+			case "ctrl+c":
+				return p, tea.Quit
+			case "enter", "esc", "ctrl+[":
+				p.filtering = false
+				p.filter.Blur()
+				return p, nil
+			}
+
+			retModel, cmd := p.filter.Update(msg)
+			p.filter = retModel.(Focusable)
+			p.applyFilter()
+			return p, cmd
+		}
+
+		switch msg.String() {
+		case "ctrl+c", "q":
+			return p, tea.Quit
+		case "<", "esc":
+			return p, switchToPreviousCmd(p.prev)
+		case "m":
+			return p, switchToMenuCmd
+		case "/":
+			p.filtering = true
+			return p, p.filter.Focus()
+		case "x":
+			p.filter.SetValues("")
+			p.applyFilter()
+		case "r", "ctrl+r":
+			p.load()
+		case "down", "j":
+			if p.cursor < len(p.entries)-1 {
+				p.cursor++
+			}
+		case "up", "k":
+			if p.cursor > 0 {
+				p.cursor--
+			}
+		case "pgdown", "right", "l":
+			p.cursor = min(p.cursor+activityRows, max(len(p.entries)-1, 0))
+		case "pgup", "left", "h":
+			p.cursor = max(p.cursor-activityRows, 0)
+		case "enter", "v":
+			if p.cursor >= len(p.entries) {
+				return p, nil
+			}
+			e := p.entries[p.cursor]
+			if e.Event == hook.RecordDeleted {
+				return p, nil
+			}
+			for _, rt := range []data.RecordType{
+				data.RecordTypeTarget, data.RecordTypeAction, data.RecordTypeSession,
+			} {
+				if strings.EqualFold(string(rt), e.RecordType) {
+					return p, switchToViewCmd(rt, e.UUID)
+				}
+			}
+		}
+	}
+
+	return p, nil
+}
+
+func (p activityPage) View() string {
+	f := datetime.Display()
+
+	title := style.TitleBarView([]string{"Activity"}, viewWidth, false)
+
+	filterView := style.Document.NormalDim.Render("Filter: ") + p.filter.View()
+	if p.err != nil {
+		filterView += "\n" + style.ErrorStyle.Render(p.err.Error())
+	} else {
+		filterView += "\n" + style.Document.NormalDim.Render(
+			fmt.Sprintf("%d of %d entries", len(p.entries), len(p.all)),
+		)
+	}
+	filterView = lipgloss.NewStyle().Width(viewWidth).Padding(0, 1).Render(filterView)
+
+	start := max(0, min(p.cursor-activityRows/2, len(p.entries)-activityRows))
+	end := min(start+activityRows, len(p.entries))
+
+	rows := make([]string, 0, activityRows)
+	for i := start; i < end; i++ {
+		e := p.entries[i]
+		marker := " "
+		if i == p.cursor {
+			marker = "›"
+		}
+		row := fmt.Sprintf(
+			"%s %s  %-*s %s",
+			marker,
+			f.DateTime(e.At),
+			activityEventWidth, e.Event,
+			e.Title,
+		)
+		row = ansi.Truncate(row, viewWidth-2, "…")
+		if i == p.cursor {
+			rows = append(rows, style.Document.Primary.Bold(true).Render(row))
+		} else {
+			rows = append(rows, style.Document.Normal.Render(row))
+		}
+	}
+	if len(p.entries) == 0 {
+		rows = append(rows, style.Document.NormalDim.Render("No activity recorded"))
+	}
+	for len(rows) < activityRows {
+		rows = append(rows, "")
+	}
+	listView := lipgloss.NewStyle().Width(viewWidth).Padding(0, 1).Render(strings.Join(rows, "\n"))
+
+	var detail []string
+	if p.cursor < len(p.entries) {
+		e := p.entries[p.cursor]
+		summary := activitySummary(e)
+		if len(summary) > activityChangeRows {
+			more := len(summary) - activityChangeRows + 1
+			summary = append(summary[:activityChangeRows-1], fmt.Sprintf("… %d more", more))
+		}
+		for _, line := range summary {
+			detail = append(detail, ansi.Truncate(line, viewWidth-4, "…"))
+		}
+	}
+	for len(detail) < activityChangeRows {
+		detail = append(detail, "")
+	}
+	detailView := style.BorderStyle["dimmed"].Width(viewWidth-2).Padding(0, 1).Render(
+		style.Document.NormalDim.Render(strings.Join(detail, "\n")),
+	)
+
+	helper := []style.HelperContent{
+		{Key: "↑/↓", Action: "navigate"},
+		{Key: "/", Action: "filter"},
+		{Key: "x", Action: "clear filter"},
+		{Key: "v", Action: "view record"},
+		{Key: "r", Action: "reload"},
+		{Key: "<", Action: "back"},
+		{Key: "q", Action: "quit"},
+	}
+	if p.filtering {
+		helper = []style.HelperContent{{Key: "Enter/Esc", Action: "done"}}
+	}
+
+	container := lipgloss.JoinVertical(
+		lipgloss.Center,
+		title,
+		filterView,
+		listView,
+		detailView,
+		style.HelperView(helper, viewWidth),
+	)
+
+	return style.ContainerStyle(p.width, container, 5).Render(container)
+}
+
+// runActivityCommand prints the activity journal, newest entries first. The
+// arguments are filter terms like on the Activity page.
+func runActivityCommand(cfg config, args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("activity", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print the entries as JSON lines")
+	limit := fs.IntP("limit", "n", 0, "print at most n entries, 0 for all")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: yatijapp-tui activity [flags] [filter terms]")
+		fmt.Fprintln(os.Stderr, "Filter terms: since:<date> until:<date> event:<pattern> type:<record type> words")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); errors.Is(err, flag.ErrHelp) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	filter, err := activity.ParseFilter(strings.Join(fs.Args(), " "), datetime.Now())
+	if err != nil {
+		return err
+	}
+	entries, err := cfg.activityLog.Entries(filter)
+	if err != nil {
+		return err
+	}
+	if *limit > 0 && len(entries) > *limit {
+		entries = entries[:*limit]
+	}
+
+	f := datetime.Display()
+	encoder := json.NewEncoder(w)
+	for _, e := range entries {
+		if *asJSON {
+			if err := encoder.Encode(e); err != nil {
+				return err
+			}
+			continue
+		}
+
+		fmt.Fprintf(w, "%s  %-*s %s\n", f.DateTime(e.At), activityEventWidth, e.Event, e.Title)
+		for _, line := range activitySummary(e) {
+			fmt.Fprintln(w, "    "+line)
+		}
+	}
+	return nil
+}
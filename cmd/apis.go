@@ -7,6 +7,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/liuminhaw/yatijapp-tui/internal/authclient"
 	"github.com/liuminhaw/yatijapp-tui/internal/data"
+	"github.com/liuminhaw/yatijapp-tui/internal/datetime"
+	"github.com/liuminhaw/yatijapp-tui/internal/hook"
 )
 
 type yatijappRecord interface {
@@ -43,7 +45,10 @@ type (
 		record yatijappRecord
 		msg    string
 	}
-	recordDeletedMsg string
+	recordDeletedMsg struct {
+		msg   string
+		event hook.Event
+	}
 
 	targetListLoadedMsg struct {
 		targets []data.Target
@@ -69,6 +74,8 @@ type (
 		msg      string
 		source   tea.Model
 		redirect tea.Model
+		events   []hook.Event
+		undo     *sessionUndo // reverts the change, when it can be undone
 	}
 
 	loadMoreRecordsMsg struct {
@@ -245,33 +252,42 @@ func loadSession(serverURL, uuid, msg string, client *authclient.AuthClient) tea
 	}
 }
 
-func deleteTarget(serverURL, uuid string, client *authclient.AuthClient) tea.Cmd {
+func deleteTarget(serverURL string, record yatijappRecord, client *authclient.AuthClient) tea.Cmd {
 	return func() tea.Msg {
-		if err := data.DeleteTarget(serverURL, uuid, client); err != nil {
+		if err := data.DeleteTarget(serverURL, record.GetUUID(), client); err != nil {
 			return err
 		}
 
-		return recordDeletedMsg("Target deleted successfully.")
+		return recordDeletedMsg{
+			msg:   "Target deleted successfully.",
+			event: deletedEvent(data.RecordTypeTarget, record.GetUUID(), record),
+		}
 	}
 }
 
-func deleteAction(serverURL, uuid string, client *authclient.AuthClient) tea.Cmd {
+func deleteAction(serverURL string, record yatijappRecord, client *authclient.AuthClient) tea.Cmd {
 	return func() tea.Msg {
-		if err := data.DeleteAction(serverURL, uuid, client); err != nil {
+		if err := data.DeleteAction(serverURL, record.GetUUID(), client); err != nil {
 			return err
 		}
 
-		return recordDeletedMsg("Action deleted successfully.")
+		return recordDeletedMsg{
+			msg:   "Action deleted successfully.",
+			event: deletedEvent(data.RecordTypeAction, record.GetUUID(), record),
+		}
 	}
 }
 
-func deleteSession(serverURL, uuid string, client *authclient.AuthClient) tea.Cmd {
+func deleteSession(serverURL string, record yatijappRecord, client *authclient.AuthClient) tea.Cmd {
 	return func() tea.Msg {
-		if err := data.DeleteSession(serverURL, uuid, client); err != nil {
+		if err := data.DeleteSession(serverURL, record.GetUUID(), client); err != nil {
 			return err
 		}
 
-		return recordDeletedMsg("Session deleted successfully.")
+		return recordDeletedMsg{
+			msg:   "Session deleted successfully.",
+			event: deletedEvent(data.RecordTypeSession, record.GetUUID(), record),
+		}
 	}
 }
 
@@ -294,6 +310,10 @@ type recordRequestData struct {
 	startsAt   time.Time
 	endsAt     sql.NullTime
 	actionUUID string
+
+	// before is the record as the page holds it, for the events of the
+	// update. Only the updated event is sent when it is nil.
+	before yatijappRecord
 }
 
 func (d recordRequestData) targetRequestBody() data.TargetRequestBody {
@@ -306,7 +326,7 @@ func (d recordRequestData) targetRequestBody() data.TargetRequestBody {
 		body.Notes = d.note.note
 	}
 	if d.dueDate != "" {
-		dueDate, err := time.ParseInLocation("2006-01-02", d.dueDate, time.Local)
+		dueDate, err := datetime.ParseAPIDate(d.dueDate)
 		if err == nil {
 			dueDateTyped := data.Date(dueDate)
 			body.DueDate = dueDateTyped.String()
@@ -326,7 +346,7 @@ func (d recordRequestData) actionRequestBody() data.ActionRequestBody {
 		body.Notes = d.note.note
 	}
 	if d.dueDate != "" {
-		dueDate, err := time.ParseInLocation("2006-01-02", d.dueDate, time.Local)
+		dueDate, err := datetime.ParseAPIDate(d.dueDate)
 		if err == nil {
 			dueDateTyped := data.Date(dueDate)
 			body.DueDate = dueDateTyped.String()
@@ -358,7 +378,8 @@ func createTarget(
 ) tea.Cmd {
 	return func() tea.Msg {
 		request := d.targetRequestBody()
-		if err := request.Create(serverURL, client); err != nil {
+		target, err := request.Create(serverURL, client)
+		if err != nil {
 			return err
 		}
 
@@ -366,6 +387,7 @@ func createTarget(
 			msg:      "Target created successfully",
 			source:   src,
 			redirect: redirect,
+			events:   []hook.Event{recordEvent(hook.TargetCreated, nil, target)},
 		}
 	}
 }
@@ -383,7 +405,8 @@ func updateTarget(
 
 	return func() tea.Msg {
 		request := d.targetRequestBody()
-		if err := request.Update(serverURL, d.uuid, client); err != nil {
+		target, err := request.Update(serverURL, d.uuid, client)
+		if err != nil {
 			return err
 		}
 
@@ -391,6 +414,7 @@ func updateTarget(
 			msg:      responseMsg,
 			source:   src,
 			redirect: redirect,
+			events:   updatedEvents(d.before, target),
 		}
 	}
 }
@@ -403,7 +427,8 @@ func createAction(
 ) tea.Cmd {
 	return func() tea.Msg {
 		request := d.actionRequestBody()
-		if err := request.Create(serverURL, client); err != nil {
+		action, err := request.Create(serverURL, client)
+		if err != nil {
 			return err
 		}
 
@@ -411,6 +436,7 @@ func createAction(
 			msg:      "Action created successfully",
 			source:   src,
 			redirect: redirect,
+			events:   []hook.Event{recordEvent(hook.ActionCreated, nil, action)},
 		}
 	}
 }
@@ -428,7 +454,8 @@ func updateAction(
 
 	return func() tea.Msg {
 		request := d.actionRequestBody()
-		if err := request.Update(serverURL, d.uuid, client); err != nil {
+		action, err := request.Update(serverURL, d.uuid, client)
+		if err != nil {
 			return err
 		}
 
@@ -436,6 +463,7 @@ func updateAction(
 			msg:      responseMsg,
 			source:   src,
 			redirect: redirect,
+			events:   updatedEvents(d.before, action),
 		}
 	}
 }
@@ -448,7 +476,8 @@ func createSession(
 ) tea.Cmd {
 	return func() tea.Msg {
 		request := d.sessionRequestBody()
-		if err := request.Create(serverURL, client); err != nil {
+		session, err := request.Create(serverURL, client)
+		if err != nil {
 			return err
 		}
 
@@ -456,6 +485,34 @@ func createSession(
 			msg:      "New session started",
 			source:   src,
 			redirect: redirect,
+			events:   []hook.Event{recordEvent(hook.SessionStarted, nil, session)},
+		}
+	}
+}
+
+// logSession creates a session which has already ended, for work done
+// without a running session.
+func logSession(
+	serverURL string,
+	d recordRequestData,
+	src, redirect tea.Model,
+	client *authclient.AuthClient,
+) tea.Cmd {
+	return func() tea.Msg {
+		request := d.sessionRequestBody()
+		session, err := request.Create(serverURL, client)
+		if err != nil {
+			return err
+		}
+
+		return apiSuccessResponseMsg{
+			msg:      "Logged " + shortDuration(d.endsAt.Time.Sub(d.startsAt)) + " session",
+			source:   src,
+			redirect: redirect,
+			events: []hook.Event{
+				recordEvent(hook.SessionStarted, nil, session),
+				recordEvent(hook.SessionEnded, nil, session),
+			},
 		}
 	}
 }
@@ -473,7 +530,8 @@ func updateSession(
 
 	return func() tea.Msg {
 		request := d.sessionRequestBody()
-		if err := request.Update(serverURL, d.uuid, client); err != nil {
+		session, err := request.Update(serverURL, d.uuid, client)
+		if err != nil {
 			return err
 		}
 
@@ -481,6 +539,7 @@ func updateSession(
 			msg:      responseMsg,
 			source:   src,
 			redirect: redirect,
+			events:   updatedEvents(d.before, session),
 		}
 	}
 }
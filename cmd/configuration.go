@@ -1,14 +1,23 @@
 package main
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 
+	"github.com/liuminhaw/yatijapp-tui/internal/activity"
 	"github.com/liuminhaw/yatijapp-tui/internal/authclient"
 	"github.com/liuminhaw/yatijapp-tui/internal/data"
+	"github.com/liuminhaw/yatijapp-tui/internal/datetime"
+	"github.com/liuminhaw/yatijapp-tui/internal/history"
+	"github.com/liuminhaw/yatijapp-tui/internal/hook"
+	"github.com/liuminhaw/yatijapp-tui/internal/searchhistory"
+	"github.com/liuminhaw/yatijapp-tui/internal/stats"
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
 	"gopkg.in/natefinch/lumberjack.v2"
@@ -22,11 +31,31 @@ type config struct {
 	displayMode string // light | dark | auto
 
 	preferences *data.Preferences
+	pomodoro    pomodoroConfig
+	watch       sessionWatchConfig
+
+	maxSessionDuration time.Duration // 0 means no limit
+	eventHooks         []hook.Hook
+	noteHistory        history.Store
+	templatesDir       string // note templates, one directory per record type
+	searchHistory      searchhistory.Store
+	activityLog        activity.Log
+	statsCache         stats.Cache
+
+	userName  string // name of the signed in user
+	userEmail string // email of the signed in user, identifies the account
 
 	logger     *slog.Logger
 	authClient *authclient.AuthClient
 }
 
+// profileKey returns the key of the user signed in to the endpoint, under
+// which the search history and the stats cache are kept.
+func profileKey(cfg config) string {
+	sum := sha256.Sum256([]byte(cfg.apiEndpoint + "\n" + cfg.userName))
+	return hex.EncodeToString(sum[:8])
+}
+
 func configSetup(
 	conf *viper.Viper,
 ) (config, error) {
@@ -46,6 +75,16 @@ func configSetup(
 
 	conf.SetDefault("api.endpoint", "https://api.yatij.app")
 	conf.SetDefault("preference.displayMode", "auto")
+	conf.SetDefault("pomodoro.work", 25*time.Minute)
+	conf.SetDefault("pomodoro.break", 5*time.Minute)
+	conf.SetDefault("pomodoro.notes", true)
+	conf.SetDefault("display.timezone", "")
+	conf.SetDefault("display.dateFormat", "iso")
+	conf.SetDefault("display.clock", "24h")
+	conf.SetDefault("display.relative", false)
+	conf.SetDefault("session.maxDuration", 24*time.Hour)
+	conf.SetDefault("session.runawayAfter", 8*time.Hour)
+	conf.SetDefault("session.idleAfter", 30*time.Minute)
 
 	conf.SetConfigName(defaultConfigFile)
 	conf.SetConfigType("toml")
@@ -58,6 +97,22 @@ func configSetup(
 	conf.BindPFlag("api.endpoint", flag.Lookup("api-endpoint"))
 	conf.BindPFlag("preference.displayMode", flag.Lookup("display-mode"))
 
+	formatter, err := datetime.NewFormatter(
+		conf.GetString("display.timezone"),
+		conf.GetString("display.dateFormat"),
+		conf.GetString("display.clock"),
+		conf.GetBool("display.relative"),
+	)
+	if err != nil {
+		return config{}, fmt.Errorf("invalid display configuration: %w", err)
+	}
+	datetime.SetDisplay(formatter)
+
+	var hooks []hook.Hook
+	if err := conf.UnmarshalKey("hooks", &hooks); err != nil {
+		return config{}, fmt.Errorf("invalid hooks configuration: %w", err)
+	}
+
 	client := &authclient.AuthClient{
 		Client:    http.DefaultClient,
 		Refresh:   authclient.RefreshToken(conf.GetString("api.endpoint")),
@@ -67,7 +122,24 @@ func configSetup(
 	return config{
 		apiEndpoint: conf.GetString("api.endpoint"),
 		displayMode: conf.GetString("preference.displayMode"),
-		logger:      logger,
-		authClient:  client,
+		pomodoro: pomodoroConfig{
+			work:  conf.GetDuration("pomodoro.work"),
+			rest:  conf.GetDuration("pomodoro.break"),
+			notes: conf.GetBool("pomodoro.notes"),
+		},
+		watch: sessionWatchConfig{
+			runawayAfter: conf.GetDuration("session.runawayAfter"),
+			idleAfter:    conf.GetDuration("session.idleAfter"),
+			stateFile:    filepath.Join(homeDir, ".yatijapp", "state.json"),
+		},
+		maxSessionDuration: conf.GetDuration("session.maxDuration"),
+		eventHooks:         hooks,
+		noteHistory:        history.Store{Dir: filepath.Join(homeDir, ".yatijapp", "note_history")},
+		templatesDir:       filepath.Join(homeDir, ".yatijapp", "templates"),
+		searchHistory:      searchhistory.Store{Dir: filepath.Join(homeDir, ".yatijapp", "search_history")},
+		activityLog:        activity.Log{Path: filepath.Join(homeDir, ".yatijapp", "activity.jsonl")},
+		statsCache:         stats.Cache{Dir: filepath.Join(homeDir, ".yatijapp", "stats_cache")},
+		logger:             logger,
+		authClient:         client,
 	}, nil
 }
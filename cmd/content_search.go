@@ -0,0 +1,220 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/liuminhaw/yatijapp-tui/colors"
+	"github.com/liuminhaw/yatijapp-tui/internal/style"
+)
+
+// contentMatch is a match of the search query, at the given line and cell
+// columns of the rendered content.
+type contentMatch struct {
+	line       int
+	start, end int
+}
+
+// contentSearch searches the rendered content of a viewport, highlighting
+// the matches and scrolling to the current one.
+type contentSearch struct {
+	input  textinput.Model
+	typing bool
+
+	query   string
+	content string // rendered content without highlights
+	matches []contentMatch
+	current int
+}
+
+func newContentSearch() contentSearch {
+	input := textinput.New()
+	input.Prompt = "/"
+	input.Placeholder = "search"
+	input.CharLimit = 80
+
+	return contentSearch{input: input}
+}
+
+// open starts typing a new query.
+func (s *contentSearch) open() tea.Cmd {
+	s.typing = true
+	s.input.SetValue(s.query)
+	s.input.CursorEnd()
+	return s.input.Focus()
+}
+
+// update handles the keys while typing the query, the query is applied to the
+// viewport on enter.
+func (s *contentSearch) update(msg tea.KeyMsg, vp *viewport.Model) tea.Cmd {
+	switch msg.String() {
+	case "enter":
+		s.typing = false
+		s.input.Blur()
+		s.query = strings.TrimSpace(s.input.Value())
+		s.matches = findMatches(s.content, s.query)
+		s.current = s.firstVisible(vp.YOffset)
+		s.apply(vp)
+		s.scrollTo(vp)
+		return nil
+	case "esc":
+		s.typing = false
+		s.input.Blur()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	s.input, cmd = s.input.Update(msg)
+	return cmd
+}
+
+// setContent sets the viewport content, with the matches of the query
+// highlighted.
+func (s *contentSearch) setContent(vp *viewport.Model, content string) {
+	s.content = content
+	s.apply(vp)
+}
+
+// clear drops the query and its highlights.
+func (s *contentSearch) clear(vp *viewport.Model) {
+	s.query = ""
+	s.matches = nil
+	s.current = 0
+	vp.SetContent(s.content)
+}
+
+func (s contentSearch) active() bool {
+	return s.query != ""
+}
+
+// next moves to the following match, or the previous one when backwards.
+func (s *contentSearch) next(vp *viewport.Model, backwards bool) {
+	if len(s.matches) == 0 {
+		return
+	}
+
+	if backwards {
+		s.current = (s.current - 1 + len(s.matches)) % len(s.matches)
+	} else {
+		s.current = (s.current + 1) % len(s.matches)
+	}
+	s.apply(vp)
+	s.scrollTo(vp)
+}
+
+// counter describes the query and the current match, like "/plan 2/5".
+func (s contentSearch) counter() string {
+	if len(s.matches) == 0 {
+		return "/" + s.query + " no matches"
+	}
+	return fmt.Sprintf("/%s %d/%d", s.query, s.current+1, len(s.matches))
+}
+
+func (s contentSearch) inputView(width int) string {
+	s.input.Width = width - 4
+	return style.BorderStyle["normal"].Width(width).Padding(0, 1).Render(s.input.View())
+}
+
+// firstVisible returns the first match from the given line on, wrapping to
+// the first match.
+func (s contentSearch) firstVisible(line int) int {
+	for i, m := range s.matches {
+		if m.line >= line {
+			return i
+		}
+	}
+	return 0
+}
+
+func (s *contentSearch) apply(vp *viewport.Model) {
+	s.matches = findMatches(s.content, s.query)
+	if s.current >= len(s.matches) {
+		s.current = 0
+	}
+	vp.SetContent(highlightMatches(s.content, s.matches, s.current))
+}
+
+func (s contentSearch) scrollTo(vp *viewport.Model) {
+	if len(s.matches) == 0 {
+		return
+	}
+
+	line := s.matches[s.current].line
+	height := vp.Height - vp.Style.GetVerticalFrameSize()
+	if line < vp.YOffset || line >= vp.YOffset+height {
+		vp.SetYOffset(line - height/2)
+	}
+}
+
+// findMatches finds the case insensitive matches of the query in the plain
+// text of the rendered content.
+func findMatches(content, query string) []contentMatch {
+	if query == "" {
+		return nil
+	}
+	query = strings.ToLower(query)
+
+	var matches []contentMatch
+	for i, line := range strings.Split(content, "\n") {
+		plain := strings.ToLower(ansi.Strip(line))
+		offset := 0
+		for {
+			idx := strings.Index(plain[offset:], query)
+			if idx < 0 {
+				break
+			}
+			start := offset + idx
+			end := start + len(query)
+			matches = append(matches, contentMatch{
+				line:  i,
+				start: ansi.StringWidth(plain[:start]),
+				end:   ansi.StringWidth(plain[:end]),
+			})
+			offset = end
+		}
+	}
+
+	return matches
+}
+
+// highlightMatches highlights the matches of the rendered content, the
+// current match stands out.
+func highlightMatches(content string, matches []contentMatch, current int) string {
+	if len(matches) == 0 {
+		return content
+	}
+
+	matchStyle := lipgloss.NewStyle().Foreground(colors.Bg).Background(colors.Warning)
+	currentStyle := lipgloss.NewStyle().Foreground(colors.Bg).Background(colors.Primary).Bold(true)
+
+	lines := strings.Split(content, "\n")
+	byLine := make(map[int][]int)
+	for i, m := range matches {
+		byLine[m.line] = append(byLine[m.line], i)
+	}
+
+	for n, indexes := range byLine {
+		line := lines[n]
+		var b strings.Builder
+		pos := 0
+		for _, i := range indexes {
+			m := matches[i]
+			st := matchStyle
+			if i == current {
+				st = currentStyle
+			}
+			b.WriteString(ansi.Cut(line, pos, m.start))
+			b.WriteString(st.Render(ansi.Strip(ansi.Cut(line, m.start, m.end))))
+			pos = m.end
+		}
+		b.WriteString(ansi.Cut(line, pos, ansi.StringWidth(line)))
+		lines[n] = b.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
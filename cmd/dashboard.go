@@ -0,0 +1,638 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/liuminhaw/yatijapp-tui/internal/authclient"
+	"github.com/liuminhaw/yatijapp-tui/internal/data"
+	"github.com/liuminhaw/yatijapp-tui/internal/datetime"
+	"github.com/liuminhaw/yatijapp-tui/internal/style"
+	"github.com/liuminhaw/yatijapp-tui/pkg/strview"
+)
+
+const (
+	dashboardItemsMax = 5
+	dashboardPageSize = 50
+	dashboardBoxWidth = viewWidth / 2
+	dashboardMaxPages = 10
+	// dashboardLookback is how long before monday the sessions of the week
+	// are paged back to, for the sessions running into the week.
+	dashboardLookback = 24 * time.Hour
+)
+
+// Sections of the dashboard, in the order of their quick keys.
+const (
+	sectionRunning = iota
+	sectionTracked
+	sectionDue
+	sectionRecent
+	sectionCount
+)
+
+type (
+	dashboardLoadedMsg struct {
+		seq     int
+		section int
+		records []yatijappRecord
+		err     error
+	}
+	dashboardTickMsg struct{ seq int }
+)
+
+type dashboardSection struct {
+	title   string
+	spinner spinner.Model
+	loading bool
+	err     error
+	records []yatijappRecord
+}
+
+// dashboardPage is the landing page after signing in, each of its sections
+// is loaded on its own.
+type dashboardPage struct {
+	cfg config
+
+	sections [sectionCount]dashboardSection
+	focus    int
+	cursor   int
+
+	// seq tells apart the loads and ticks of the latest reload.
+	seq int
+
+	width  int
+	height int
+
+	popupModels []tea.Model
+	popup       string
+
+	msg string
+}
+
+func newDashboardPage(cfg config, termSize style.ViewSize) dashboardPage {
+	page := dashboardPage{
+		cfg:         cfg,
+		width:       termSize.Width,
+		height:      termSize.Height,
+		popupModels: []tea.Model{},
+	}
+	for i, title := range []string{"Running", "Tracked", "Due", "Recently active"} {
+		page.sections[i] = dashboardSection{
+			title:   title,
+			spinner: spinner.New(spinner.WithSpinner(spinner.Line)),
+		}
+	}
+
+	return page
+}
+
+func (d dashboardPage) Init() tea.Cmd {
+	return d.reload()
+}
+
+// reload loads all the sections again, concurrently.
+func (d *dashboardPage) reload() tea.Cmd {
+	d.seq++
+	loaders := [sectionCount]func(string, *authclient.AuthClient) ([]yatijappRecord, error){
+		sectionRunning: loadRunningSessions,
+		sectionTracked: loadWeekSessions,
+		sectionDue:     loadDueRecords,
+		sectionRecent:  loadRecentActions,
+	}
+
+	cmds := []tea.Cmd{dashboardTick(d.seq)}
+	for i, load := range loaders {
+		d.sections[i].loading = true
+		d.sections[i].err = nil
+		cmds = append(cmds, d.sections[i].spinner.Tick, dashboardLoad(
+			d.seq, i, load, d.cfg.apiEndpoint, d.cfg.authClient,
+		))
+	}
+
+	return tea.Batch(cmds...)
+}
+
+func dashboardLoad(
+	seq, section int,
+	load func(string, *authclient.AuthClient) ([]yatijappRecord, error),
+	serverURL string,
+	client *authclient.AuthClient,
+) tea.Cmd {
+	return func() tea.Msg {
+		records, err := load(serverURL, client)
+		return dashboardLoadedMsg{seq: seq, section: section, records: records, err: err}
+	}
+}
+
+// dashboardTick refreshes the elapsed time of the running sessions.
+func dashboardTick(seq int) tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return dashboardTickMsg{seq: seq}
+	})
+}
+
+func loadRunningSessions(serverURL string, client *authclient.AuthClient) ([]yatijappRecord, error) {
+	resp, err := data.ListSessions(data.ListRequestInfo{
+		ServerURL:    serverURL,
+		QueryStrings: map[string]string{"status": "in progress", "sort": "-starts_at"},
+	}, client)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []yatijappRecord
+	for _, session := range resp.Sessions {
+		if !session.EndsAt.Valid {
+			records = append(records, session)
+		}
+	}
+	return records, nil
+}
+
+// loadWeekSessions loads the sessions tracked this week, paging back until
+// the sessions start well before monday.
+func loadWeekSessions(serverURL string, client *authclient.AuthClient) ([]yatijappRecord, error) {
+	week := datetime.Week(datetime.Now())
+	earliest := week.From.Add(-dashboardLookback)
+
+	var records []yatijappRecord
+	for page := 1; page <= dashboardMaxPages; page++ {
+		resp, err := data.ListSessions(data.ListRequestInfo{
+			ServerURL: serverURL,
+			QueryStrings: map[string]string{
+				"sort":      "-starts_at",
+				"page":      strconv.Itoa(page),
+				"page_size": strconv.Itoa(dashboardPageSize),
+			},
+		}, client)
+		if err != nil {
+			return nil, err
+		}
+
+		done := len(resp.Sessions) < dashboardPageSize || page >= resp.Metadata.LastPage
+		for _, session := range resp.Sessions {
+			if session.StartsAt.Before(earliest) {
+				done = true
+				break
+			}
+			records = append(records, session)
+		}
+		if done {
+			break
+		}
+	}
+	return records, nil
+}
+
+// loadDueRecords loads the unfinished targets and actions which are overdue
+// or due today, earliest due first.
+func loadDueRecords(serverURL string, client *authclient.AuthClient) ([]yatijappRecord, error) {
+	due := datetime.DayRange{To: datetime.Now()}
+
+	targets, err := pageDueRecords(due, func(info data.ListRequestInfo) ([]yatijappRecord, data.Metadata, error) {
+		resp, err := data.ListTargets(info, client)
+		if err != nil {
+			return nil, data.Metadata{}, err
+		}
+		records := make([]yatijappRecord, len(resp.Targets))
+		for i, target := range resp.Targets {
+			records[i] = target
+		}
+		return records, resp.Metadata, nil
+	}, serverURL)
+	if err != nil {
+		return nil, err
+	}
+	actions, err := pageDueRecords(due, func(info data.ListRequestInfo) ([]yatijappRecord, data.Metadata, error) {
+		resp, err := data.ListActions(info, client)
+		if err != nil {
+			return nil, data.Metadata{}, err
+		}
+		records := make([]yatijappRecord, len(resp.Actions))
+		for i, action := range resp.Actions {
+			records[i] = action
+		}
+		return records, resp.Metadata, nil
+	}, serverURL)
+	if err != nil {
+		return nil, err
+	}
+
+	records := append(targets, actions...)
+	slices.SortStableFunc(records, func(a, b yatijappRecord) int {
+		aDue, _ := a.GetDueDate()
+		bDue, _ := b.GetDueDate()
+		return aDue.Compare(bDue)
+	})
+	return records, nil
+}
+
+// pageDueRecords pages through the unfinished records sorted by due date,
+// keeping the ones due within the range, until the due dates pass it.
+// Records with no due date are skipped wherever they are sorted.
+func pageDueRecords(
+	due datetime.DayRange,
+	list func(info data.ListRequestInfo) ([]yatijappRecord, data.Metadata, error),
+	serverURL string,
+) ([]yatijappRecord, error) {
+	var records []yatijappRecord
+	for page := 1; page <= dashboardMaxPages; page++ {
+		resp, metadata, err := list(data.ListRequestInfo{
+			ServerURL: serverURL,
+			QueryStrings: map[string]string{
+				"status":    "queued,in progress",
+				"sort":      "due_date",
+				"page":      strconv.Itoa(page),
+				"page_size": strconv.Itoa(dashboardPageSize),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		done := len(resp) < dashboardPageSize || page >= metadata.LastPage
+		for _, r := range resp {
+			t, ok := r.GetDueDate()
+			if !ok {
+				continue
+			}
+			if !due.Contains(t) {
+				done = true
+				break
+			}
+			records = append(records, r)
+		}
+		if done {
+			break
+		}
+	}
+	return records, nil
+}
+
+func loadRecentActions(serverURL string, client *authclient.AuthClient) ([]yatijappRecord, error) {
+	resp, err := data.ListActions(data.ListRequestInfo{
+		ServerURL: serverURL,
+		QueryStrings: map[string]string{
+			"sort":      "-last_active",
+			"page_size": strconv.Itoa(dashboardItemsMax),
+		},
+	}, client)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]yatijappRecord, len(resp.Actions))
+	for i, action := range resp.Actions {
+		records[i] = action
+	}
+	return records, nil
+}
+
+// trackedTime sums the time of the sessions between from and to, up to now
+// for the running sessions.
+func trackedTime(records []yatijappRecord, from, to time.Time) time.Duration {
+	var total time.Duration
+	for _, r := range records {
+		session, ok := r.(data.Session)
+		if !ok {
+			continue
+		}
+		start, end := sessionInterval(session.StartsAt, session.EndsAt)
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if end.After(start) {
+			total += end.Sub(start)
+		}
+	}
+	return total
+}
+
+func (d dashboardPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		d.width = msg.Width
+		d.height = msg.Height
+	case tea.KeyMsg:
+		if d.popup != "" {
+			break
+		}
+		d.msg = ""
+		switch msg.String() {
+		case "ctrl+c", "q":
+			return d, tea.Quit
+		case "1", "2", "3", "4":
+			d.focus = int(msg.Runes[0] - '1')
+			d.cursor = 0
+		case "tab":
+			d.focus = (d.focus + 1) % sectionCount
+			d.cursor = 0
+		case "shift+tab":
+			d.focus = (d.focus + sectionCount - 1) % sectionCount
+			d.cursor = 0
+		case "down", "j":
+			if d.cursor < len(d.items())-1 {
+				d.cursor++
+			}
+		case "up", "k":
+			if d.cursor > 0 {
+				d.cursor--
+			}
+		case "enter":
+			items := d.items()
+			if d.cursor < len(items) {
+				record := items[d.cursor]
+				return d, switchToViewCmd(record.GetActualType(), record.GetUUID())
+			}
+			return d, d.openList()
+		case "o":
+			return d, d.openList()
+		case "r", "ctrl+r":
+			return d, d.reload()
+		case "m":
+			return d, switchToMenuCmd
+		case "ctrl+/", "ctrl+_":
+			return d, switchToSearchCmd(data.RecordTypeAll)
+		}
+		return d, nil
+	case showSearchMsg:
+		popupModel := newSearchPage(
+			d.cfg, msg.scope, style.ViewSize{Width: d.width, Height: d.height}, "", d,
+		)
+		d.popupModels = append(d.popupModels, popupModel)
+		d.popup = popupModel.View()
+	case switchToPreviousMsg:
+		return d, d.reload()
+	case apiSuccessResponseMsg:
+		d.msg = msg.msg
+		return d, d.reload()
+	case dashboardLoadedMsg:
+		if msg.seq != d.seq {
+			return d, nil
+		}
+		var ue data.UnauthorizedApiDataErr
+		if errors.As(msg.err, &ue) {
+			d.cfg.logger.Error(
+				ue.Error(), slog.Int("status", ue.Status), slog.String("occurence", "dashboard"),
+			)
+			return d, switchToMenuCmd
+		}
+		if msg.err != nil {
+			d.cfg.logger.Error(msg.err.Error(), slog.String("occurence", "dashboard"))
+		}
+		section := &d.sections[msg.section]
+		section.loading = false
+		section.err = msg.err
+		section.records = msg.records
+		d.cursor = min(d.cursor, max(len(d.items())-1, 0))
+		return d, nil
+	case dashboardTickMsg:
+		if msg.seq != d.seq {
+			return d, nil
+		}
+		return d, dashboardTick(d.seq)
+	case spinner.TickMsg:
+		var cmds []tea.Cmd
+		for i := range d.sections {
+			if d.sections[i].loading {
+				d.sections[i].spinner, cmd = d.sections[i].spinner.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+		}
+		return d, tea.Batch(cmds...)
+	}
+
+	if len(d.popupModels) > 0 {
+		lastIndex := len(d.popupModels) - 1
+		d.popupModels[lastIndex], cmd = d.popupModels[lastIndex].Update(msg)
+		d.popup = d.popupModels[lastIndex].View()
+		return d, cmd
+	}
+
+	return d, nil
+}
+
+// items returns the records listed in the focused section.
+func (d dashboardPage) items() []yatijappRecord {
+	return d.listed(d.focus)
+}
+
+// listed returns the records listed in the section, the tracked sessions
+// are only summed up.
+func (d dashboardPage) listed(section int) []yatijappRecord {
+	s := d.sections[section]
+	if s.loading || s.err != nil || section == sectionTracked {
+		return nil
+	}
+	return s.records[:min(len(s.records), dashboardItemsMax)]
+}
+
+// openList opens the list of the records behind the focused section.
+func (d dashboardPage) openList() tea.Cmd {
+	switch d.focus {
+	case sectionRunning:
+		f := d.cfg.preferences.GetFilter(data.RecordTypeSession).Filter
+		f.Status = []string{"in progress"}
+		return switchToSavedViewCmd(data.View{RecordType: data.RecordTypeSession, Filter: f})
+	case sectionDue:
+		recordType := data.RecordTypeAction
+		if items := d.items(); d.cursor < len(items) {
+			recordType = items[d.cursor].GetActualType()
+		}
+		f := d.cfg.preferences.GetFilter(recordType).Filter
+		f.SortBy, f.SortOrder = "due_date", "ascending"
+		f.Due, f.DueFrom, f.DueTo = data.DueCustom, "", "today"
+		return switchToSavedViewCmd(data.View{RecordType: recordType, Filter: f})
+	case sectionRecent:
+		f := d.cfg.preferences.GetFilter(data.RecordTypeAction).Filter
+		f.SortBy, f.SortOrder = "last_active", "descending"
+		return switchToSavedViewCmd(data.View{RecordType: data.RecordTypeAction, Filter: f})
+	default:
+		return switchToSessionsCmd
+	}
+}
+
+func (d dashboardPage) closePopups() tea.Model {
+	d.popupModels = []tea.Model{}
+	d.popup = ""
+	return d
+}
+
+func (d dashboardPage) View() string {
+	now := datetime.Now()
+
+	title := style.TitleBarView([]string{"Dashboard"}, viewWidth, false)
+
+	running := d.sectionView(sectionRunning, 2, func(width int) []string {
+		var rows []string
+		for i, r := range d.listed(sectionRunning) {
+			session := r.(data.Session)
+			rows = append(rows, d.rowView(
+				sectionRunning, i, session.ActionTitle, formatElapsed(now.Sub(session.StartsAt)), style.WarningStyle, width,
+			))
+		}
+		if len(rows) == 0 {
+			rows = append(rows, style.Document.NormalDim.Render("No session running"))
+		}
+		return rows
+	})
+
+	tracked := d.sectionView(sectionTracked, 2, func(width int) []string {
+		records := d.sections[sectionTracked].records
+		today := datetime.StartOfDay(now)
+		week := datetime.Week(now)
+		return []string{
+			d.rowView(
+				sectionTracked, -1, "Today",
+				formatTracked(trackedTime(records, today, today.AddDate(0, 0, 1))),
+				style.Document.Highlight, width,
+			),
+			d.rowView(
+				sectionTracked, -1, "This week",
+				formatTracked(trackedTime(records, week.From, week.To.AddDate(0, 0, 1))),
+				style.Document.Highlight, width,
+			),
+		}
+	})
+
+	due := d.sectionView(sectionDue, dashboardItemsMax, func(width int) []string {
+		formatter := datetime.Display()
+		formatter.Relative = true
+		overdue := datetime.DayRange{To: now.AddDate(0, 0, -1)}
+
+		var rows []string
+		for i, r := range d.listed(sectionDue) {
+			dueDate, _ := r.GetDueDate()
+			label, st := "today", style.WarningStyle
+			if overdue.Contains(dueDate) {
+				label, st = formatter.Due(dueDate), style.ErrorStyle
+			}
+			itemTitle := string(r.GetActualType()[0]) + " " + r.GetTitle()
+			rows = append(rows, d.rowView(sectionDue, i, itemTitle, label, st, width))
+		}
+		if len(rows) == 0 {
+			rows = append(rows, style.Document.NormalDim.Render("Nothing due"))
+		}
+		return rows
+	})
+
+	recent := d.sectionView(sectionRecent, dashboardItemsMax, func(width int) []string {
+		var rows []string
+		for i, r := range d.listed(sectionRecent) {
+			rows = append(rows, d.rowView(
+				sectionRecent, i, r.GetTitle(),
+				datetime.Relative(r.GetLastActive(), now), style.Document.NormalDim, width,
+			))
+		}
+		if len(rows) == 0 {
+			rows = append(rows, style.Document.NormalDim.Render("No actions yet"))
+		}
+		return rows
+	})
+
+	msgView := style.MsgStyle.Width(viewWidth).AlignHorizontal(lipgloss.Center).Render(d.msg)
+
+	helperView := style.HelperView([]style.HelperContent{
+		{Key: "1-4/tab", Action: "section"},
+		{Key: "↑/↓", Action: "navigate"},
+		{Key: "Enter", Action: "view"},
+		{Key: "o", Action: "open list"},
+		{Key: "r", Action: "reload"},
+		{Key: "ctrl+/", Action: "search"},
+		{Key: "m", Action: "menu"},
+		{Key: "q", Action: "quit"},
+	}, viewWidth)
+
+	container := lipgloss.JoinVertical(
+		lipgloss.Center,
+		title,
+		lipgloss.JoinHorizontal(lipgloss.Top, running, tracked),
+		lipgloss.JoinHorizontal(lipgloss.Top, due, recent),
+		msgView,
+		helperView,
+	)
+
+	if d.popup != "" {
+		overlayX := lipgloss.Width(container)/2 - lipgloss.Width(d.popup)/2
+		overlayY := lipgloss.Height(container)/2 - lipgloss.Height(d.popup)/2
+		container = strview.PlaceOverlay(overlayX, overlayY, d.popup, container)
+	}
+	return style.ContainerStyle(d.width, container, 5).Render(container)
+}
+
+// sectionView renders the section in a box of the given content height, with
+// the spinner or the error of the section while it has no records to show.
+func (d dashboardPage) sectionView(section, height int, rows func(width int) []string) string {
+	s := d.sections[section]
+	width := dashboardBoxWidth - 4
+
+	header := style.Document.NormalDim.Render(fmt.Sprintf("%d ", section+1))
+	if section == d.focus {
+		header += style.Document.Highlight.Render(s.title)
+	} else {
+		header += style.Document.Normal.Render(s.title)
+	}
+
+	var body string
+	switch {
+	case s.loading:
+		s.spinner.Style = style.Document.Highlight
+		body = s.spinner.View() + style.Document.NormalDim.Render(" loading...")
+	case s.err != nil:
+		body = style.ErrorStyle.Width(width).Render(ansi.Truncate(apiErrorText(s.err), 2*width, "…"))
+	default:
+		body = strings.Join(rows(width), "\n")
+	}
+
+	border := style.BorderStyle["normal"]
+	if section == d.focus {
+		border = style.BorderStyle["highlighted"]
+	}
+	return border.Width(dashboardBoxWidth-2).Height(height+1).Padding(0, 1).Render(
+		header + "\n" + body,
+	)
+}
+
+// rowView renders a row of title and label, the label flushed right. Row i
+// of the focused section is highlighted at the cursor.
+func (d dashboardPage) rowView(section, i int, title, label string, labelStyle lipgloss.Style, width int) string {
+	titleStyle := style.Document.Normal
+	if section == d.focus && i == d.cursor {
+		titleStyle = style.Document.Highlight
+		title = "> " + title
+	}
+	titleWidth := width - lipgloss.Width(label) - 1
+	title = ansi.Truncate(title, titleWidth, "…")
+	return titleStyle.Width(titleWidth).Render(title) + " " + labelStyle.Render(label)
+}
+
+func formatTracked(d time.Duration) string {
+	return fmt.Sprintf("%dh %02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+func formatElapsed(d time.Duration) string {
+	return fmt.Sprintf("%d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+}
+
+// apiErrorText returns the message of the API error for display.
+func apiErrorText(err error) string {
+	var ue data.UnexpectedApiDataErr
+	if errors.As(err, &ue) {
+		return ue.Msg
+	}
+	return err.Error()
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/liuminhaw/yatijapp-tui/internal/authclient"
+	"github.com/liuminhaw/yatijapp-tui/internal/data"
+	"github.com/liuminhaw/yatijapp-tui/internal/style"
+)
+
+const (
+	// splitViewMinWidth is the terminal width from which the list page shows
+	// the detail pane next to the list.
+	splitViewMinWidth = 160
+	splitViewGap      = 2
+	detailMaxWidth    = 120
+	detailDebounce    = 250 * time.Millisecond
+)
+
+type (
+	detailFetchMsg struct {
+		seq        int
+		uuid       string
+		recordType data.RecordType
+	}
+	detailLoadedMsg struct {
+		uuid   string
+		record yatijappRecord
+	}
+	detailLoadErrMsg struct {
+		uuid string
+		err  error
+	}
+)
+
+// detailPane shows the full details of the highlighted list record on wide
+// terminals. Fetched records are cached by uuid, and fetches are debounced
+// so that scrolling through the list does not flood the API.
+type detailPane struct {
+	viewport viewport.Model
+	cache    map[string]yatijappRecord
+
+	seq     int
+	uuid    string
+	record  yatijappRecord
+	loading bool
+	err     error
+
+	search contentSearch
+}
+
+func newDetailPane() detailPane {
+	vp := viewport.New(viewWidth, 20)
+	vp.Style = style.BorderStyle["focused"]
+
+	return detailPane{
+		viewport: vp,
+		cache:    make(map[string]yatijappRecord),
+		search:   newContentSearch(),
+	}
+}
+
+// request shows the given record in the pane, fetching it after the debounce
+// interval when it is not cached yet.
+func (d *detailPane) request(record yatijappRecord) tea.Cmd {
+	if record == nil {
+		d.uuid = ""
+		d.record = nil
+		d.search.setContent(&d.viewport, "")
+		return nil
+	}
+
+	uuid := record.GetUUID()
+	if uuid == d.uuid && (d.loading || d.record != nil) {
+		return nil
+	}
+	d.uuid = uuid
+	d.err = nil
+
+	if cached, ok := d.cache[uuid]; ok {
+		d.record = cached
+		d.loading = false
+		d.err = d.render()
+		return nil
+	}
+
+	d.seq++
+	d.record = nil
+	d.loading = true
+	msg := detailFetchMsg{seq: d.seq, uuid: uuid, recordType: record.GetActualType()}
+	return tea.Tick(detailDebounce, func(time.Time) tea.Msg { return msg })
+}
+
+// fetch returns the command to load the record of a debounced fetch request,
+// or nil if the cursor has moved on since the request was scheduled.
+func (d *detailPane) fetch(
+	msg detailFetchMsg,
+	serverURL string,
+	client *authclient.AuthClient,
+) tea.Cmd {
+	if msg.seq != d.seq || msg.uuid != d.uuid {
+		return nil
+	}
+
+	return loadDetailRecord(serverURL, msg.uuid, msg.recordType, client)
+}
+
+func (d *detailPane) loaded(msg detailLoadedMsg) {
+	d.cache[msg.uuid] = msg.record
+	if msg.uuid != d.uuid {
+		return
+	}
+
+	d.record = msg.record
+	d.loading = false
+	d.err = d.render()
+}
+
+func (d *detailPane) failed(msg detailLoadErrMsg) {
+	if msg.uuid != d.uuid {
+		return
+	}
+
+	d.loading = false
+	d.err = msg.err
+}
+
+// invalidate drops all cached records, the current record is fetched again
+// on the next request.
+func (d *detailPane) invalidate() {
+	clear(d.cache)
+	d.uuid = ""
+	d.record = nil
+}
+
+func (d *detailPane) resize(width, height int) {
+	if d.viewport.Width == width && d.viewport.Height == height {
+		return
+	}
+
+	d.viewport.Width = width
+	d.viewport.Height = height
+	if d.record != nil {
+		d.err = d.render()
+	}
+}
+
+func (d *detailPane) render() error {
+	str, err := renderMarkdown(recordMarkdown(d.record), d.viewport)
+	if err != nil {
+		return err
+	}
+	d.search.setContent(&d.viewport, str)
+	d.viewport.GotoTop()
+
+	return nil
+}
+
+func (d detailPane) view(s *spinner.Model, dim bool) string {
+	if dim {
+		d.viewport.Style = style.BorderStyle["dimmed"]
+	} else {
+		d.viewport.Style = style.BorderStyle["focused"]
+	}
+
+	innerWidth := d.viewport.Width - d.viewport.Style.GetHorizontalFrameSize()
+	innerHeight := d.viewport.Height - d.viewport.Style.GetVerticalFrameSize()
+	placeholder := lipgloss.NewStyle().
+		Width(innerWidth).
+		Height(innerHeight).
+		Align(lipgloss.Center, lipgloss.Center)
+
+	switch {
+	case d.err != nil:
+		return d.viewport.Style.Render(
+			placeholder.Render(style.ErrorStyle.Render("Error: " + d.err.Error())),
+		)
+	case d.loading:
+		s.Style = style.Document.Highlight
+		return d.viewport.Style.Render(
+			placeholder.Render(s.View() + " " + style.Document.NormalDim.Bold(true).Render("loading...")),
+		)
+	case d.record == nil:
+		return d.viewport.Style.Render(
+			placeholder.Render(style.Document.NormalDim.Render("No record selected")),
+		)
+	}
+
+	return d.viewport.View()
+}
+
+func loadDetailRecord(
+	serverURL, uuid string,
+	rt data.RecordType,
+	client *authclient.AuthClient,
+) tea.Cmd {
+	return func() tea.Msg {
+		var record yatijappRecord
+		var err error
+		switch rt {
+		case data.RecordTypeTarget:
+			record, err = data.GetTarget(serverURL, uuid, client)
+		case data.RecordTypeAction:
+			record, err = data.GetAction(serverURL, uuid, client)
+		case data.RecordTypeSession:
+			record, err = data.GetSession(serverURL, uuid, client)
+		default:
+			panic("unsupported record type in loadDetailRecord")
+		}
+		if err != nil {
+			return detailLoadErrMsg{uuid: uuid, err: err}
+		}
+
+		return detailLoadedMsg{uuid: uuid, record: record}
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/liuminhaw/yatijapp-tui/internal/data"
+	"github.com/liuminhaw/yatijapp-tui/internal/hook"
+)
+
+type hookFinishedMsg struct {
+	hook   hook.Hook
+	event  hook.Event
+	output string
+	err    error
+}
+
+// recordEvent creates an event of the given name for the record change, the
+// record type and uuid are taken from whichever record is not nil.
+func recordEvent(name string, before, after yatijappRecord) hook.Event {
+	e := hook.Event{Name: name}
+
+	record := after
+	if record == nil {
+		record = before
+	}
+	if record != nil {
+		e.RecordType = strings.ToLower(string(record.GetActualType()))
+		e.UUID = record.GetUUID()
+	}
+	if before != nil {
+		e.Previous = before
+	}
+	if after != nil {
+		e.Record = after
+	}
+
+	return e
+}
+
+// deletedEvent creates the event for a deleted record, before is the record
+// as it was before the deletion if known.
+func deletedEvent(rt data.RecordType, uuid string, before yatijappRecord) hook.Event {
+	e := hook.Event{
+		Name:       hook.RecordDeleted,
+		RecordType: strings.ToLower(string(rt)),
+		UUID:       uuid,
+	}
+	if before != nil {
+		e.Previous = before
+	}
+
+	return e
+}
+
+// updatedEvents returns the events for a record update. Besides the generic
+// update event, action status changes and ended sessions get events of
+// their own.
+func updatedEvents(before, after yatijappRecord) []hook.Event {
+	switch after := after.(type) {
+	case data.Target:
+		return []hook.Event{recordEvent(hook.TargetUpdated, before, after)}
+	case data.Action:
+		events := []hook.Event{recordEvent(hook.ActionUpdated, before, after)}
+		if before != nil && before.GetStatus() != after.Status {
+			events = append(events, recordEvent(hook.ActionStatusChanged, before, after))
+		}
+		return events
+	case data.Session:
+		events := []hook.Event{recordEvent(hook.SessionUpdated, before, after)}
+		if session, ok := before.(data.Session); ok && !session.EndsAt.Valid && after.EndsAt.Valid {
+			events = append(events, recordEvent(hook.SessionEnded, before, after))
+		}
+		return events
+	default:
+		panic("unsupported record type in updatedEvents")
+	}
+}
+
+// runEventHooks runs the configured hooks matching the events, each hook in
+// a command of its own.
+func runEventHooks(cfg config, events []hook.Event) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, event := range events {
+		for _, h := range cfg.eventHooks {
+			if !h.Match(event.Name) {
+				continue
+			}
+
+			cmds = append(cmds, func() tea.Msg {
+				output, err := h.Run(
+					context.Background(),
+					event,
+					"YATIJAPP_API_ENDPOINT="+cfg.apiEndpoint,
+				)
+				return hookFinishedMsg{hook: h, event: event, output: output, err: err}
+			})
+		}
+	}
+
+	return tea.Batch(cmds...)
+}
+
+func logHookFinished(logger *slog.Logger, msg hookFinishedMsg) {
+	attrs := []any{
+		slog.String("action", "run hook"),
+		slog.String("event", msg.event.Name),
+		slog.String("uuid", msg.event.UUID),
+		slog.String("command", msg.hook.Command),
+		slog.String("output", msg.output),
+	}
+	if msg.err != nil {
+		logger.Error(msg.err.Error(), attrs...)
+		return
+	}
+	logger.Info("hook finished", attrs...)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/liuminhaw/yatijapp-tui/colors"
+	"github.com/liuminhaw/yatijapp-tui/internal/datetime"
 	"github.com/liuminhaw/yatijapp-tui/internal/model"
 	"github.com/liuminhaw/yatijapp-tui/internal/style"
 	"github.com/liuminhaw/yatijapp-tui/internal/validator"
@@ -19,6 +20,8 @@ type inputFieldConfig struct {
 	lenMax      int // optional max length limit, 0 means no limit
 	isSecret    bool
 	validators  []func(string) error
+	hint        func(string) string // optional hint shown under a valid value
+	highlight   func(string) string // optional styling of the value
 }
 
 func nameInput(width int, focus bool, recordType string) Focusable {
@@ -43,8 +46,9 @@ func dueInput(width int, focus bool, validators ...func(string) error) Focusable
 		placeholder: "YYYY-mm-dd",
 		lenMax:      0,
 		validators: []func(string) error{
-			validator.ValidateDateTime(validator.ValidDateFormats),
+			validator.ValidateDate(),
 		},
+		hint: dateHint,
 	}
 
 	for _, validator := range validators {
@@ -54,29 +58,48 @@ func dueInput(width int, focus bool, validators ...func(string) error) Focusable
 	return generalInput(c)
 }
 
+// descriptionInput is a multi-line field, edited in the external or the
+// built-in editor.
 func descriptionInput(width int, focus bool) Focusable {
+	m := model.NewNoteModel()
+	m.Title = "Description"
+	m.PreviewLines = 2
+	m.Width = width
+	m.CharLimit = 200
+	m.ValidateFunc = validator.ValidateMaxLength(200)
+	if focus {
+		m.Focus()
+	}
+
+	return m
+}
+
+func timeInput(width int, focus bool) Focusable {
 	c := inputFieldConfig{
 		width:       width,
 		focus:       focus,
-		placeholder: "Information about the record",
-		lenMax:      200,
+		placeholder: "YYYY-mm-dd HH:MM:SS",
+		lenMax:      0,
 		validators: []func(string) error{
-			validator.ValidateReachMaxLength(200),
+			validator.ValidateDateTime(),
 		},
+		hint: dateTimeHint,
 	}
 
 	return generalInput(c)
 }
 
-func timeInput(width int, focus bool) Focusable {
+func durationInput(width int, focus bool) Focusable {
 	c := inputFieldConfig{
 		width:       width,
 		focus:       focus,
-		placeholder: "YYYY-mm-dd HH:MM:SS",
+		placeholder: "1h30m",
 		lenMax:      0,
 		validators: []func(string) error{
-			validator.ValidateDateTime(validator.ValidDateTimeFormats),
+			validator.ValidateRequired("required"),
+			validator.ValidateDuration(),
 		},
+		hint: durationHint,
 	}
 
 	return generalInput(c)
@@ -162,7 +185,35 @@ func generalInput(c inputFieldConfig) Focusable {
 	}
 	field.Validate = validator.MultipleValidators(c.validators...)
 
-	return model.NewTextInputWrapper(field)
+	input := model.NewTextInputWrapper(field)
+	input.HintFunc = c.hint
+	input.HighlightFunc = c.highlight
+
+	return input
+}
+
+func dateHint(input string) string {
+	t, err := datetime.ParseDate(input, datetime.Now())
+	if err != nil {
+		return ""
+	}
+	return datetime.Display().DueDate(t)
+}
+
+func durationHint(input string) string {
+	d, err := datetime.ParseDuration(input)
+	if err != nil {
+		return ""
+	}
+	return shortDuration(d)
+}
+
+func dateTimeHint(input string) string {
+	t, err := datetime.Parse(input, datetime.Now())
+	if err != nil {
+		return ""
+	}
+	return datetime.Display().DateTime(t) + t.Format(" Mon")
 }
 
 func inputsValidation(inputs []Focusable, msg string) error {
@@ -222,12 +273,17 @@ func (f field) simpleTitlePrompt(
 }
 
 func (f field) textInputPrompt(name, helper string, styling ...lipgloss.Style) string {
+	message := style.FormFieldStyle.Error.Render(f.obj.Error())
+	if h, ok := f.obj.(interface{ Hint() string }); ok && f.obj.Error() == "" && h.Hint() != "" {
+		message = style.FormFieldStyle.Helper.Render("→ " + h.Hint())
+	}
+
 	prompt := fmt.Sprintf(
 		"%s %s\n%s\n%s",
 		style.FormFieldStyle.Prompt(name, f.obj.Focused()),
 		style.FormFieldStyle.Helper.Render(helper),
 		style.FormFieldStyle.Content.Render(f.obj.View()),
-		style.FormFieldStyle.Error.Render(f.obj.Error()),
+		message,
 	)
 
 	appliedStyle := lipgloss.NewStyle()
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -55,6 +56,9 @@ func newFilterPage(
 	}
 
 	focusables = append(focusables, sortBy, sortOrder, status)
+	if f.RecordType != data.RecordTypeSession {
+		focusables = append(focusables, dueFilterFields(f.Filter)...)
+	}
 	focused := 0
 	focusables[focused].Focus()
 
@@ -95,6 +99,12 @@ func (m filterPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// m.focusedCache = m.focused
 			return m, m.fields[m.focused].Focus()
 		case "ctrl+s":
+			for _, f := range m.fields {
+				f.Validate()
+				if f.Error() != "" {
+					return m, nil
+				}
+			}
 			return m, switchToPreviousCmd(m.prevPage())
 		case "esc", "ctrl+[":
 			return m, switchToPreviousCmd(m.prev)
@@ -185,6 +195,10 @@ func (m filterPage) View() string {
 		),
 	)
 
+	if len(m.fields) > 3 {
+		filterForm = lipgloss.JoinVertical(lipgloss.Left, filterForm, m.dueView())
+	}
+
 	helperContent := []style.HelperContent{
 		{Key: "Esc", Action: "back"},
 		{Key: "Tab/Shift+Tab", Action: "navigate"},
@@ -202,15 +216,47 @@ func (m filterPage) View() string {
 	return style.ContainerStyle(m.width, container, 5).Render(container)
 }
 
+// dueFilterFields returns the due preset and the custom range fields.
+func dueFilterFields(f data.Filter) []Focusable {
+	preset := model.NewRadioModel(model.DueOptions, formWidth)
+	value := f.Due
+	if value == data.DueAny {
+		value = "any"
+	}
+	if err := preset.SetValues(value); err != nil {
+		panic("failed to set due values: " + err.Error())
+	}
+
+	from := dueInput(formWidth/2-2, false)
+	to := dueInput(formWidth/2-2, false)
+	if f.DueFrom != "" {
+		from.SetValues(f.DueFrom)
+	}
+	if f.DueTo != "" {
+		to.SetValues(f.DueTo)
+	}
+
+	return []Focusable{preset, from, to}
+}
+
 func (m filterPage) getFilter() data.RecordFilter {
-	return data.RecordFilter{
-		RecordType: m.recordType,
-		Filter: data.Filter{
-			SortBy:    m.fields[0].Value(),
-			SortOrder: m.fields[1].Value(),
-			Status:    m.fields[2].Values(),
-		},
+	filter := data.Filter{
+		SortBy:    m.fields[0].Value(),
+		SortOrder: m.fields[1].Value(),
+		Status:    m.fields[2].Values(),
 	}
+	if len(m.fields) > 3 {
+		filter.Due = m.fields[3].Value()
+		if filter.Due == "any" {
+			filter.Due = data.DueAny
+		}
+		if filter.Due == data.DueCustom {
+			filter.DueFrom = strings.TrimSpace(m.fields[4].Value())
+			filter.DueTo = strings.TrimSpace(m.fields[5].Value())
+		}
+	}
+
+	return data.RecordFilter{RecordType: m.recordType, Filter: filter}
 }
 
 func (m filterPage) prevPage() tea.Model {
@@ -243,3 +289,30 @@ func (m filterPage) prevPage() tea.Model {
 
 	return m.prev
 }
+
+// dueView renders the due preset, and the custom range bounds.
+func (m filterPage) dueView() string {
+	preset := field{idx: 3, obj: m.fields[3]}
+	from := field{idx: 4, obj: m.fields[4]}
+	to := field{idx: 5, obj: m.fields[5]}
+
+	presetView := preset.prompt(
+		preset.simpleTitlePrompt("Due", "(Use ←/→ keys to select)", false),
+		preset.simpleTitlePrompt("Due", fmt.Sprintf("<f%d>", preset.idx+1), false),
+	) + "\n" + style.FormFieldStyle.Content.Render(preset.obj.View())
+
+	rangeStyle := lipgloss.NewStyle().Width(formWidth / 2)
+	if preset.obj.Value() != data.DueCustom {
+		rangeStyle = rangeStyle.Faint(true)
+	}
+	rangeView := lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		rangeStyle.Render(from.textInputPrompt("From", fmt.Sprintf("<f%d>", from.idx+1))),
+		rangeStyle.Render(to.textInputPrompt("To", fmt.Sprintf("<f%d>", to.idx+1))),
+	)
+
+	return style.BorderStyle["normal"].BorderTop(true).
+		Width(formWidth).
+		Margin(0, 5).
+		Render(presetView + "\n\n" + rangeView)
+}
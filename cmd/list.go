@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"slices"
 	"strings"
 	"time"
@@ -14,7 +15,9 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/liuminhaw/yatijapp-tui/internal/authclient"
 	"github.com/liuminhaw/yatijapp-tui/internal/data"
+	"github.com/liuminhaw/yatijapp-tui/internal/datetime"
 	"github.com/liuminhaw/yatijapp-tui/internal/model"
+	"github.com/liuminhaw/yatijapp-tui/internal/query"
 	"github.com/liuminhaw/yatijapp-tui/internal/style"
 	"github.com/liuminhaw/yatijapp-tui/pkg/strview"
 )
@@ -22,7 +25,7 @@ import (
 type listHooks struct {
 	loadAll func(info data.ListRequestInfo, msg, src string, client *authclient.AuthClient) tea.Cmd
 	load    func(serverURL, uuid, msg string, rt data.RecordType, client *authclient.AuthClient) tea.Cmd
-	delete  func(serverURL, uuid string, client *authclient.AuthClient) tea.Cmd
+	delete  func(serverURL string, record yatijappRecord, client *authclient.AuthClient) tea.Cmd
 	update  func(
 		serverURL, msg string,
 		d recordRequestData,
@@ -54,7 +57,17 @@ type listPage struct {
 	popupModels []tea.Model
 	popup       string
 
-	filter data.RecordFilter
+	filter  data.RecordFilter
+	search  string // search query, its status and sort override the filter
+	view    string // name of the saved view the list was opened from
+	detail  detailPane
+	yanking bool // waiting for the key following the y prefix
+
+	// Sessions marked for merging, and the last split or merge to undo
+	marked map[string]bool
+	undo   *sessionUndo
+
+	progress targetsProgress // completed actions of the targets shown
 }
 
 func newListPage(cfg config, termSize style.ViewSize, prev tea.Model) listPage {
@@ -67,6 +80,7 @@ func newListPage(cfg config, termSize style.ViewSize, prev tea.Model) listPage {
 		loading:     true,
 		prev:        prev,
 		popupModels: []tea.Model{},
+		detail:      newDetailPane(),
 	}
 }
 
@@ -172,6 +186,9 @@ func (l listPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, cmd)
 		l.popup = l.popupModels[len(l.popupModels)-1].View()
 	case tea.KeyMsg:
+		if l.detail.search.typing || l.yanking {
+			break
+		}
 		switch msg.String() {
 		case "?":
 			l.clearMsg()
@@ -188,25 +205,61 @@ func (l listPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		l.width = msg.Width
 		l.height = msg.Height
+		cmds = append(cmds, l.refreshDetail())
 	case tea.KeyMsg:
 		if l.popup != "" {
 			break
 		}
+		if l.detail.search.typing {
+			return l, l.detail.search.update(msg, &l.detail.viewport)
+		}
+		if l.yanking {
+			l.yanking = false
+			return l, l.yank(msg.String())
+		}
 		switch msg.String() {
+		case "y":
+			l.clearMsg()
+			l.yanking = true
+			return l, nil
 		case "ctrl+c", "q":
 			return l, tea.Quit
 		case "up", "k":
 			l.clearMsg()
-			return l, l.selection.prev()
+			cmd = l.selection.prev()
+			return l, tea.Batch(cmd, l.refreshDetail(), l.loadProgress())
 		case "down", "j":
 			l.clearMsg()
-			return l, l.selection.next()
+			cmd = l.selection.next()
+			return l, tea.Batch(cmd, l.refreshDetail(), l.loadProgress())
 		case "right", "l":
 			l.clearMsg()
-			return l, l.selection.nextPage()
+			cmd = l.selection.nextPage()
+			return l, tea.Batch(cmd, l.refreshDetail(), l.loadProgress())
 		case "left", "h":
 			l.clearMsg()
-			return l, l.selection.prevPage()
+			cmd = l.selection.prevPage()
+			return l, tea.Batch(cmd, l.refreshDetail(), l.loadProgress())
+		case "ctrl+d":
+			if l.splitView() {
+				l.detail.viewport.HalfPageDown()
+			}
+			return l, nil
+		case "ctrl+u":
+			if l.splitView() {
+				l.detail.viewport.HalfPageUp()
+			}
+			return l, nil
+		case "ctrl+f":
+			if l.splitView() {
+				return l, l.detail.search.open()
+			}
+			return l, nil
+		case "ctrl+n", "ctrl+p":
+			if l.splitView() {
+				l.detail.search.next(&l.detail.viewport, msg.String() == "ctrl+p")
+			}
+			return l, nil
 		case "enter":
 			if l.selection.hasRecords() {
 				selected := l.selection.current()
@@ -228,6 +281,7 @@ func (l listPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							uuid:       selected.GetUUID(),
 							endsAt:     sql.NullTime{Valid: true, Time: time.Now()},
 							actionUUID: selected.GetParentsUUID()[data.RecordTypeAction],
+							before:     selected,
 						},
 						l, l,
 						l.cfg.authClient,
@@ -294,7 +348,7 @@ func (l listPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					prompts = []string{"Proceed to delete session \"" + selected.GetTitle() + "\"?"}
 				}
 			}
-			deleteCmd := l.hooks.delete(l.cfg.apiEndpoint, selected.GetUUID(), l.cfg.authClient)
+			deleteCmd := l.hooks.delete(l.cfg.apiEndpoint, selected, l.cfg.authClient)
 			popupModel = model.NewAlert(
 				"Confirm Deletion", "confirmation", prompts, warnings, 60,
 				map[string]tea.Cmd{"confirm": deleteCmd, "cancel": cancelPopupCmd},
@@ -304,8 +358,53 @@ func (l listPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return l, confirmationCmd
 		case "n":
 			return l, switchToCreateCmd(l.recordType, l.src)
+		case "p":
+			if l.recordType == data.RecordTypeTarget && l.selection.hasRecords() {
+				return l, switchToProgressCmd(l.selection.current())
+			}
+		case "t":
+			if l.recordType == data.RecordTypeSession {
+				return l, func() tea.Msg { return showSessionLogMsg{parents: l.src} }
+			}
+		case " ":
+			if l.recordType == data.RecordTypeSession && l.selection.hasRecords() {
+				l.toggleMarked(l.selection.current().GetUUID())
+				return l, nil
+			}
+		case "s":
+			if l.recordType == data.RecordTypeSession && l.selection.hasRecords() {
+				popupModel, err := newSessionSplitPage(
+					l.cfg,
+					style.ViewSize{Width: l.width, Height: l.height},
+					l.selection.current().(data.Session),
+					l,
+				)
+				if err != nil {
+					l.cfg.logger.Error(err.Error(), slog.String("action", "show split session popup"))
+					return l, tea.Quit
+				}
+				l.popupModels = append(l.popupModels, popupModel)
+				l.popup = l.popupModels[len(l.popupModels)-1].View()
+				return l, nil
+			}
+		case "M":
+			if l.recordType == data.RecordTypeSession {
+				l.clearMsg()
+				return l, previewSessionMerge(l.cfg.apiEndpoint, l.markedUUIDs(), l.cfg.authClient)
+			}
+		case "u":
+			if l.recordType == data.RecordTypeSession && l.undo != nil {
+				l.clearMsg()
+				return l, undoSessionEdit(l.cfg.apiEndpoint, *l.undo, l, l, l.cfg.authClient)
+			}
 		case "f":
 			return l, switchToFilterCmd(l.filter)
+		case "S":
+			l.clearMsg()
+			popupModel = newSaveViewPage(l.cfg, l.savedView())
+			l.popupModels = append(l.popupModels, popupModel)
+			l.popup = popupModel.View()
+			return l, nil
 		case "/":
 			return l, switchToSearchCmd(l.recordType)
 		case "ctrl+/", "ctrl+_":
@@ -314,6 +413,7 @@ func (l listPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return l, switchToMenuCmd
 		case "ctrl+r":
 			l.clearMsg()
+			l.detail.invalidate()
 			return l, l.hooks.loadAll(
 				data.ListRequestInfo{
 					ServerURL:    l.cfg.apiEndpoint,
@@ -323,6 +423,15 @@ func (l listPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				"Records refreshed", "list", l.cfg.authClient,
 			)
 		}
+	case viewsUpdatedMsg:
+		if msg.err != nil {
+			l.cfg.logger.Error(msg.err.Error(), slog.String("action", "save view"))
+			l.msg = msg.err.Error()
+		} else {
+			l.view = msg.view
+			l.msg = msg.msg
+		}
+		return l, nil
 	case cancelPopupMsg:
 		l.popupModels = l.popupModels[:len(l.popupModels)-1]
 		if len(l.popupModels) == 0 {
@@ -357,18 +466,40 @@ func (l listPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		l.cfg.logger.Info("new session popup", slog.Any("record", l.popupModels))
 
+		l.popupModels = append(l.popupModels, popupModel)
+		l.popup = l.popupModels[len(l.popupModels)-1].View()
+	case showSessionLogMsg:
+		var record yatijappRecord
+		if !msg.parents.IsEmpty() {
+			record = data.Session{
+				TargetUUID:  msg.parents.UUID(data.RecordTypeTarget),
+				TargetTitle: msg.parents.Title(data.RecordTypeTarget),
+				ActionUUID:  msg.parents.UUID(data.RecordTypeAction),
+				ActionTitle: msg.parents.Title(data.RecordTypeAction),
+			}
+		}
+
+		var err error
+		popupModel, err = newSessionLogPage(
+			l.cfg, style.ViewSize{Width: l.width, Height: l.height}, record, l,
+		)
+		if err != nil {
+			l.cfg.logger.Error(err.Error(), slog.String("action", "show log session popup"))
+			return l, tea.Quit
+		}
+
 		l.popupModels = append(l.popupModels, popupModel)
 		l.popup = l.popupModels[len(l.popupModels)-1].View()
 	case showSearchMsg:
-		search := l.selection.query["search"]
 		popupModel = newSearchPage(
-			l.cfg, msg.scope, style.ViewSize{Width: l.width, Height: l.height}, search, l,
+			l.cfg, msg.scope, style.ViewSize{Width: l.width, Height: l.height}, l.search, l,
 		)
 		l.popupModels = append(l.popupModels, popupModel)
 		l.popup = l.popupModels[len(l.popupModels)-1].View()
 	case switchToPreviousMsg:
 		l.loading = true
 		l.clearMsg()
+		l.detail.invalidate()
 		return l, tea.Batch(
 			l.spinner.Tick,
 			l.hooks.loadAll(
@@ -388,6 +519,34 @@ func (l listPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		l.msg = msg.msg
 		l.selection.setRecords(msg, l.cfg.logger)
 		l.loading = false
+		l.progress.reset()
+		cmds = append(cmds, l.refreshDetail(), l.loadProgress())
+	case targetProgressMsg:
+		if msg.err != nil {
+			l.cfg.logger.Error(
+				msg.err.Error(),
+				slog.String("occurence", "list page target progress"),
+				slog.String("uuid", msg.uuid),
+			)
+		}
+		l.progress.loaded(msg)
+		return l, nil
+	case yankedMsg:
+		l.msg = yankMessage(msg)
+		return l, nil
+	case detailFetchMsg:
+		return l, l.detail.fetch(msg, l.cfg.apiEndpoint, l.cfg.authClient)
+	case detailLoadedMsg:
+		l.detail.loaded(msg)
+		return l, nil
+	case detailLoadErrMsg:
+		l.cfg.logger.Error(
+			msg.err.Error(),
+			slog.String("occurence", "list page detail pane"),
+			slog.String("uuid", msg.uuid),
+		)
+		l.detail.failed(msg)
+		return l, nil
 	case getRecordLoadedMsg:
 		l.loading = false
 		return l, switchToEditCmd(l.recordType, msg.record)
@@ -399,19 +558,32 @@ func (l listPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			l.popup = l.popupModels[len(l.popupModels)-1].View()
 		}
 		l.clearMsg()
+		l.detail.invalidate()
 		return l, l.hooks.loadAll(
 			data.ListRequestInfo{
 				ServerURL:    l.cfg.apiEndpoint,
 				SrcUUID:      l.src.UUID(l.recordType.GetParentType()),
 				QueryStrings: l.selection.query,
 			},
-			string(msg), "list", l.cfg.authClient,
+			msg.msg, "list", l.cfg.authClient,
 		)
 	case confirmationMsg:
 		l.loading = false
+	case sessionMergePreviewMsg:
+		done := l
+		done.marked = nil
+		popupModel = sessionMergeAlert(
+			msg.sessions,
+			mergeSessions(l.cfg.apiEndpoint, msg.sessions, l, done, l.cfg.authClient),
+		)
+		l.popupModels = append(l.popupModels, popupModel)
+		l.popup = l.popupModels[len(l.popupModels)-1].View()
+		return l, confirmationCmd
 	case apiSuccessResponseMsg:
 		l.loading = true
+		l.undo = msg.undo
 		l.clearMsg()
+		l.detail.invalidate()
 		l.cfg.logger.Info("api success", slog.Any("src", l.src))
 		return l, l.hooks.loadAll(
 			data.ListRequestInfo{
@@ -486,25 +658,39 @@ func (l listPage) View() string {
 
 	var title string
 	if l.msg == "" {
+		var contents []string
 		switch l.recordType {
 		case data.RecordTypeTarget:
-			title = style.TitleBarView([]string{"Targets"}, viewWidth, false)
+			contents = []string{"Targets"}
 		case data.RecordTypeAction:
 			if t := l.src.Title(l.recordType.GetParentType()); t != "" {
-				title = style.TitleBarView([]string{t, "Actions"}, viewWidth, false)
+				contents = []string{t, "Actions"}
 			} else {
-				title = style.TitleBarView([]string{"Actions"}, viewWidth, false)
+				contents = []string{"Actions"}
 			}
 		case data.RecordTypeSession:
 			if a := l.src.Title(l.recordType.GetParentType()); a != "" {
 				t := l.src.Title(data.RecordTypeTarget)
-				title = style.TitleBarView([]string{t, a, "Sessions"}, viewWidth, false)
+				contents = []string{t, a, "Sessions"}
 			} else {
-				title = style.TitleBarView([]string{"Sessions"}, viewWidth, false)
+				contents = []string{"Sessions"}
 			}
 		default:
 			panic("unsupported record type in list page view")
 		}
+		if l.view != "" {
+			label := "view: " + l.view
+			if v, ok := l.cfg.preferences.View(l.view); !ok || !v.Equal(l.savedView()) {
+				label += " (modified)"
+			}
+			contents = append(contents, label)
+		} else if l.search != "" {
+			contents = append(contents, "search: "+l.search)
+		}
+		if l.splitView() && l.detail.search.active() {
+			contents = append(contents, l.detail.search.counter())
+		}
+		title = style.TitleBarView(contents, viewWidth, false)
 	} else {
 		title = style.TitleBarView([]string{l.msg}, viewWidth, true)
 	}
@@ -524,13 +710,21 @@ func (l listPage) View() string {
 	var content strings.Builder
 	start, end := l.selection.p.GetSliceBounds(len(l.selection.records))
 	for i, record := range l.selection.records[start:end] {
-		content.WriteString(
-			record.ListItemView(
-				l.src[l.recordType.GetParentType()] == data.RecordParent{},
-				i+start == l.selection.selected,
-				viewWidth,
-			),
+		chosen := i+start == l.selection.selected
+		item := record.ListItemView(
+			l.src[l.recordType.GetParentType()] == data.RecordParent{},
+			chosen,
+			viewWidth,
 		)
+		if target, ok := record.(data.Target); ok {
+			if completed, ok := l.progress.completedActions(target.UUID); ok {
+				item = target.ListItemProgressView(completed, chosen, viewWidth)
+			}
+		}
+		if l.marked[record.GetUUID()] {
+			item = strings.Replace(item, "∎", markedGlyph, 1)
+		}
+		content.WriteString(item)
 	}
 
 	for i := len(l.selection.records[start:end]); i < l.selection.p.PerPage; i++ {
@@ -546,15 +740,45 @@ func (l listPage) View() string {
 		l.selection.view(),
 	)
 
+	if l.yanking {
+		items := []style.HelperContent{{Key: "c", Action: "checklist"}}
+		if l.recordType == data.RecordTypeSession {
+			items = append(items, style.HelperContent{Key: "s", Action: "summary"})
+		}
+		helperView = yankHelper(items, viewWidth)
+	}
+
+	if l.splitView() {
+		if l.detail.search.typing {
+			helperView = l.detail.search.inputView(viewWidth)
+		}
+		container = lipgloss.JoinVertical(lipgloss.Center, container, helperView)
+		container = lipgloss.JoinHorizontal(
+			lipgloss.Top,
+			container,
+			strings.Repeat(" ", splitViewGap),
+			l.detail.view(&l.spinner, l.popup != ""),
+		)
+		if l.popup != "" {
+			overlayX := lipgloss.Width(container)/2 - lipgloss.Width(l.popup)/2
+			overlayY := lipgloss.Height(container)/2 - lipgloss.Height(l.popup)/2
+			container = strview.PlaceOverlay(overlayX, overlayY, l.popup, container)
+		}
+		return style.ContainerStyle(l.width, container, 5).Render(container)
+	}
+
 	if len(l.selection.records) > 0 {
 		selected := l.selection.current()
-		detailView := data.ListPageDetailView(
-			selected.ListItemDetailView(
-				l.src[l.recordType.GetParentType()] == data.RecordParent{},
-				viewWidth,
-			),
-			l.popup != "",
+		detail := selected.ListItemDetailView(
+			l.src[l.recordType.GetParentType()] == data.RecordParent{},
+			viewWidth,
 		)
+		if target, ok := selected.(data.Target); ok {
+			if completed, ok := l.progress.completedActions(target.UUID); ok {
+				detail = target.ListItemDetailProgressView(completed, viewWidth)
+			}
+		}
+		detailView := data.ListPageDetailView(detail, l.popup != "")
 		container = lipgloss.JoinVertical(lipgloss.Center, container, detailView)
 	}
 	container = lipgloss.JoinVertical(lipgloss.Center, container, helperView)
@@ -567,6 +791,81 @@ func (l listPage) View() string {
 	return style.ContainerStyle(l.width, container, 5).Render(container)
 }
 
+// splitView reports whether the terminal is wide enough to show the detail
+// pane next to the list.
+func (l listPage) closePopups() tea.Model {
+	l.popupModels = []tea.Model{}
+	l.popup = ""
+	return l
+}
+
+func (l listPage) splitView() bool {
+	return l.width >= splitViewMinWidth
+}
+
+// refreshDetail resizes the detail pane to the current terminal size and
+// requests the details of the highlighted record.
+func (l *listPage) refreshDetail() tea.Cmd {
+	if !l.splitView() {
+		return nil
+	}
+
+	width := min(l.width-viewWidth-splitViewGap-4, detailMaxWidth)
+	height := max(l.height-10, l.selection.p.PerPage+4)
+	l.detail.resize(width, height)
+
+	return l.detail.request(l.selection.current())
+}
+
+// loadProgress counts the completed actions of the targets on the page shown.
+func (l listPage) loadProgress() tea.Cmd {
+	if l.recordType != data.RecordTypeTarget {
+		return nil
+	}
+
+	start, end := l.selection.p.GetSliceBounds(len(l.selection.records))
+	return l.progress.load(l.cfg.apiEndpoint, l.selection.records[start:end], l.cfg.authClient)
+}
+
+func (l *listPage) toggleMarked(uuid string) {
+	marked := make(map[string]bool, len(l.marked)+1)
+	for k := range l.marked {
+		marked[k] = true
+	}
+	if l.marked[uuid] {
+		delete(marked, uuid)
+	} else {
+		marked[uuid] = true
+	}
+	l.marked = marked
+}
+
+func (l listPage) markedUUIDs() []string {
+	uuids := make([]string, 0, len(l.marked))
+	for uuid := range l.marked {
+		uuids = append(uuids, uuid)
+	}
+	return uuids
+}
+
+// yank copies the part of the highlighted record the key stands for, or the
+// checklist of the records on the page.
+func (l listPage) yank(key string) tea.Cmd {
+	if key == "c" {
+		start, end := l.selection.p.GetSliceBounds(len(l.selection.records))
+		return yankCmd("checklist", recordsChecklist(l.selection.records[start:end]))
+	}
+	if !l.selection.hasRecords() {
+		return nil
+	}
+
+	record := l.selection.current()
+	if l.detail.record != nil && l.detail.record.GetUUID() == record.GetUUID() {
+		record = l.detail.record
+	}
+	return yankRecord(key, record, recordMarkdown(record))
+}
+
 func (l *listPage) clearMsg() {
 	l.msg = ""
 }
@@ -582,13 +881,39 @@ func (l *listPage) helperPopup(width int) {
 		"e":     "Edit " + strings.ToLower(recordType),
 		"d":     "Delete " + strings.ToLower(recordType),
 		"f":     "Filter",
+		"S":     "Save view",
 		"m":     "Menu",
 		"/":     "Search",
 		"<C-/>": "Search all",
 		"<C-r>": "Refresh",
+		"y":     "Yank (copy)",
 		"?":     "Toggle helper",
 	}
-	order := []string{"<", "↑/↓", "q", "n", "v", "e", "d", "f", "m", "/", "<C-/>", "<C-r>", "?"}
+	order := []string{"<", "↑/↓", "q", "n", "v", "e", "d", "f", "S", "m", "/", "<C-/>", "<C-r>", "y", "?"}
+
+	if l.recordType == data.RecordTypeSession {
+		items["t"] = "Log time"
+		items["s"] = "Split session"
+		items["Space"] = "Mark for merge"
+		items["M"] = "Merge marked"
+		order = slices.Insert(order, 4, "t", "s", "Space", "M")
+		if l.undo != nil {
+			items["u"] = "Undo " + l.undo.label
+			order = slices.Insert(order, 8, "u")
+		}
+	}
+
+	if l.recordType == data.RecordTypeTarget {
+		items["p"] = "Progress"
+		order = slices.Insert(order, 4, "p")
+	}
+
+	if l.splitView() {
+		items["<C-d>/<C-u>"] = "Scroll details"
+		items["<C-f>"] = "Search details"
+		items["<C-n>/<C-p>"] = "Next/previous match"
+		order = slices.Insert(order, len(order)-1, "<C-d>/<C-u>", "<C-f>", "<C-n>/<C-p>")
+	}
 
 	var enterValue string
 	if l.recordType == data.RecordTypeSession && l.selection.hasRecords() &&
@@ -637,20 +962,62 @@ func (l listPage) listPageHelper(width int) string {
 }
 
 func (l *listPage) selectionFilterQuery(f data.RecordFilter) {
-	if f.Filter.SortOrder == "ascending" {
-		l.selection.query["sort"] = f.Filter.SortKey()
-	} else {
-		l.selection.query["sort"] = "-" + f.Filter.SortKey()
-	}
-	l.selection.query["status"] = strings.Join(f.Filter.Status, ",")
-
 	l.filter = f
+	l.selectionQuery()
 }
 
 func (l *listPage) selectionSearchQuery(k string) {
-	l.selection.query["search"] = k
+	l.search = k
+	l.selectionQuery()
 }
 
 func (l *listPage) selectionSearchClear() {
-	delete(l.selection.query, "search")
+	l.search = ""
+	l.selectionQuery()
+}
+
+// savedView returns the record type, search and filter of the list as a view.
+func (l listPage) savedView() data.View {
+	return data.View{
+		Name:       l.view,
+		RecordType: l.recordType,
+		Search:     l.search,
+		Filter:     l.filter.Filter,
+	}
+}
+
+// selectionQuery sets the query strings from the filter and the search
+// query, the terms of the search take precedence.
+func (l *listPage) selectionQuery() {
+	f := l.filter
+	now := datetime.Now()
+	if f.Filter.SortOrder == "ascending" {
+		l.selection.query["sort"] = f.Filter.SortKey()
+	} else {
+		l.selection.query["sort"] = "-" + f.Filter.SortKey()
+	}
+	l.selection.query["status"] = strings.Join(f.Filter.Status, ",")
+	for _, key := range []string{"search", "page", "due_from", "due_to"} {
+		delete(l.selection.query, key)
+	}
+	maps.Copy(l.selection.query, f.Filter.DueQueryStrings(now))
+
+	var matches []func(yatijappRecord) bool
+	if _, ok := f.Filter.DueRange(now); ok || f.Filter.Due == data.DueNone {
+		matches = append(matches, func(r yatijappRecord) bool {
+			due, hasDue := r.GetDueDate()
+			return f.Filter.MatchDue(due, hasDue, now)
+		})
+	}
+
+	if l.search != "" {
+		q, err := query.Parse(l.search, now)
+		if err != nil {
+			l.cfg.logger.Error(err.Error(), slog.String("action", "parse search query"))
+		} else {
+			maps.Copy(l.selection.query, q.QueryStrings())
+			matches = append(matches, queryMatch(q))
+		}
+	}
+	l.selection.match = matchAll(matches)
 }
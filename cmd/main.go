@@ -1,12 +1,18 @@
 package main
 
 import (
+	"fmt"
 	"log/slog"
+	"os"
+	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/liuminhaw/yatijapp-tui/internal/data"
+	"github.com/liuminhaw/yatijapp-tui/internal/hook"
 	"github.com/liuminhaw/yatijapp-tui/internal/style"
+	"github.com/liuminhaw/yatijapp-tui/pkg/strview"
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
@@ -30,6 +36,7 @@ func main() {
 
 	flag.String("api-endpoint", "https://api.yatij.app", "yatijapp server api endpoint")
 	flag.String("display-mode", "auto", "display mode: light | dark | auto")
+	flag.CommandLine.SetInterspersed(false)
 	flag.Parse()
 
 	cfg, err := configSetup(vConf)
@@ -37,6 +44,14 @@ func main() {
 		panic(err)
 	}
 
+	if flag.Arg(0) == "activity" {
+		if err := runActivityCommand(cfg, flag.Args()[1:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	switch cfg.displayMode {
 	case "light":
 		cfg.logger.Info("Using light display mode")
@@ -61,11 +76,39 @@ type mainModel struct {
 	active tea.Model
 	width  int
 	height int
+
+	// prompt is a popup shown on top of any active page, it takes all the
+	// key inputs until dismissed.
+	prompt tea.Model
+	notice string
+
+	pomodoro    *pomodoro
+	pomodoroSeq int
+
+	// lastActive is the last time a key input was seen, used to detect
+	// sessions which were left running.
+	lastActive   time.Time
+	lastSaved    time.Time
+	keptSessions map[string]bool
+	// watching tells if open sessions are watched, which starts once the
+	// user is loaded. watchSeq tells the ticks of each watch apart.
+	watching bool
+	watchSeq int
 }
 
 func newMainModel(cfg config) mainModel {
+	lastActive, err := loadLastActive(cfg.watch.stateFile)
+	if err != nil {
+		cfg.logger.Error(err.Error(), slog.String("action", "load last active time"))
+	}
+	if lastActive.IsZero() {
+		lastActive = time.Now()
+	}
+
 	return mainModel{
-		cfg: cfg,
+		cfg:          cfg,
+		lastActive:   lastActive,
+		keptSessions: make(map[string]bool),
 	}
 }
 
@@ -74,21 +117,150 @@ func (m mainModel) Init() tea.Cmd {
 }
 
 func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	var cmd tea.Cmd
+	var cmd, hookCmd, historyCmd, activityCmd, watchCmd tea.Cmd
 
 	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		m.notice = ""
+		m.lastActive = time.Now()
+		if m.lastActive.Sub(m.lastSaved) >= activitySaveInterval {
+			m.lastSaved = m.lastActive
+			if err := saveLastActive(m.cfg.watch.stateFile, m.lastActive); err != nil {
+				m.cfg.logger.Error(err.Error(), slog.String("action", "save last active time"))
+			}
+		}
+		if m.prompt != nil {
+			m.prompt, cmd = m.prompt.Update(msg)
+			return m, cmd
+		}
+	case pomodoroStartedMsg:
+		m.pomodoroSeq++
+		round := 1
+		if m.pomodoro != nil && m.pomodoro.phase == pomodoroWaiting &&
+			m.pomodoro.session.ActionUUID == msg.session.ActionUUID {
+			round = m.pomodoro.round + 1
+		}
+		if m.pomodoro != nil && m.pomodoro.phase != pomodoroWaiting {
+			m.notice = fmt.Sprintf(
+				"Pomodoro #%d of %q replaced, its session is left running",
+				m.pomodoro.round, m.pomodoro.session.ActionTitle,
+			)
+		}
+		m.pomodoro = newPomodoro(m.pomodoroSeq, m.cfg.pomodoro, msg.session, round)
+		if msg.success == nil {
+			if m.notice == "" {
+				m.notice = fmt.Sprintf("Pomodoro #%d started", round)
+			}
+			return m, tea.Batch(m.pomodoroEventsCmd(msg.events), m.pomodoro.tick())
+		}
+		next, cmd := m.Update(*msg.success)
+		return next, tea.Batch(cmd, m.pomodoro.tick())
+	case pomodoroTickMsg:
+		if m.pomodoro == nil || msg.id != m.pomodoro.id {
+			return m, nil
+		}
+		switch m.pomodoro.phase {
+		case pomodoroWork:
+			if m.pomodoro.remaining() <= 0 {
+				m.pomodoro.phase = pomodoroEnding
+				return m, endPomodoroInterval(m.cfg.apiEndpoint, m.pomodoro, m.cfg.authClient)
+			}
+		case pomodoroBreak:
+			if m.pomodoro.remaining() <= 0 {
+				m.pomodoro.phase = pomodoroWaiting
+				m.prompt = m.pomodoro.nextPrompt()
+				return m, nil
+			}
+		default:
+			return m, nil
+		}
+		return m, m.pomodoro.tick()
+	case pomodoroIntervalEndedMsg:
+		if m.pomodoro == nil || msg.id != m.pomodoro.id {
+			return m, nil
+		}
+		if msg.stopped {
+			m.cfg.logger.Info("pomodoro session ended elsewhere, stop pomodoro")
+			m.pomodoro = nil
+			m.notice = "Pomodoro stopped, session was already ended"
+			return m, nil
+		}
+		m.pomodoro.startBreak()
+		m.notice = fmt.Sprintf("Pomodoro #%d done, time for a break", m.pomodoro.round)
+		return m, tea.Batch(m.pomodoroEventsCmd(msg.events), m.pomodoro.tick())
+	case pomodoroErrMsg:
+		if m.pomodoro == nil || msg.id != m.pomodoro.id {
+			return m, nil
+		}
+		m.cfg.logger.Error(msg.err.Error(), slog.String("action", "end pomodoro session"))
+		m.pomodoro = nil
+		m.notice = "Pomodoro stopped, failed to end session"
+		return m, nil
+	case pomodoroContinueMsg:
+		m.prompt = nil
+		if m.pomodoro == nil {
+			return m, nil
+		}
+		return m, nextPomodoroSession(m.cfg.apiEndpoint, m.pomodoro, m.cfg.authClient)
+	case pomodoroStopMsg:
+		m.prompt = nil
+		m.pomodoro = nil
+		return m, nil
+	case sessionWatchTickMsg:
+		if !m.watching || msg.seq != m.watchSeq {
+			return m, nil
+		}
+		if m.prompt != nil {
+			return m, sessionWatchTick(m.watchSeq)
+		}
+		return m, tea.Batch(
+			loadOpenSessions(m.watchSeq, m.cfg.apiEndpoint, m.cfg.authClient),
+			sessionWatchTick(m.watchSeq),
+		)
+	case openSessionsFoundMsg:
+		if m.prompt != nil || !m.watching || msg.seq != m.watchSeq {
+			return m, nil
+		}
+		now := time.Now()
+		for _, session := range msg.sessions {
+			if m.keptSessions[session.UUID] ||
+				(m.pomodoro != nil && m.pomodoro.session.UUID == session.UUID) {
+				continue
+			}
+			if endsAt, reason, ok := forgottenSession(m.cfg.watch, session, m.lastActive, now); ok {
+				m.prompt = forgottenSessionPrompt(session, endsAt, reason)
+				break
+			}
+		}
+		return m, nil
+	case sessionWatchErrMsg:
+		m.cfg.logger.Info(msg.err.Error(), slog.String("action", "check open sessions"))
+		return m, nil
+	case sessionWatchEndMsg:
+		m.prompt = nil
+		return m, endForgottenSession(m.cfg.apiEndpoint, msg, m.active, m.cfg.authClient)
+	case sessionWatchKeepMsg:
+		m.prompt = nil
+		m.keptSessions[msg.uuid] = true
+		return m, nil
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 		if !m.ready {
 			m.ready = true
-			return m, switchToMenuCmd
+			return m, switchToHomeCmd
 		}
 	case switchToPreviousMsg:
 		m.active = msg.model
 	case switchToMenuMsg:
 		m.active = newMenuPage(m.cfg, m.width, m.height)
 		return m, m.active.Init()
+	case switchToHomeMsg:
+		m.active = newHomePage(m.cfg, m.width, m.height)
+		return m, m.active.Init()
+	case switchToDashboardMsg:
+		m.active = newDashboardPage(m.cfg, style.ViewSize{Width: m.width, Height: m.height})
+		return m, m.active.Init()
 	case switchToSigninMsg:
 		m.active = newSigninPage(m.cfg, style.ViewSize{Width: m.width, Height: m.height})
 	case switchToSignupMsg:
@@ -174,6 +346,25 @@ func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		}
 		m.active = page
+	case openSearchResultMsg:
+		m.active = msg.prev
+		return m, switchToViewCmd(msg.record.GetActualType(), msg.record.GetUUID())
+	case switchToActivityMsg:
+		m.active = newActivityPage(m.cfg, style.ViewSize{Width: m.width, Height: m.height}, m.active)
+	case switchToStatsMsg:
+		m.active = newStatsPage(m.cfg, style.ViewSize{Width: m.width, Height: m.height}, m.active)
+		return m, m.active.Init()
+	case switchToProgressMsg:
+		m.active = newProgressPage(
+			m.cfg, style.ViewSize{Width: m.width, Height: m.height}, msg.uuid, msg.title, m.active,
+		)
+		return m, m.active.Init()
+	case switchToSavedViewMsg:
+		page := newSavedViewListPage(
+			m.cfg, style.ViewSize{Width: m.width, Height: m.height}, msg.view, m.active,
+		)
+		m.active = page
+		return m, m.active.Init()
 	case switchToActionsMsg:
 		m.active = newActionListPage(
 			m.cfg,
@@ -237,21 +428,105 @@ func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case selectorActionSelectedMsg:
 		m.active = msg.model
 	case obtainPreferencesMsg:
-		m.cfg.preferences = &msg.preferences
+		m.cfg.preferences = msg.preferences
+	case viewsUpdatedMsg:
+		// pages share the preferences through the pointer
+		if msg.err == nil && m.cfg.preferences != nil {
+			*m.cfg.preferences = msg.preferences
+		}
+	case obtainUserMsg:
+		m.cfg.userName = msg.user.Name
+		m.cfg.userEmail = msg.user.Email
+		if !m.watching {
+			m.watching = true
+			m.watchSeq++
+			// Sessions left open from the previous run are checked against
+			// the last active time saved by that run.
+			watchCmd = tea.Batch(
+				loadOpenSessions(m.watchSeq, m.cfg.apiEndpoint, m.cfg.authClient),
+				sessionWatchTick(m.watchSeq),
+			)
+		}
+	case signedOutMsg:
+		m.active = msg.redirect
+		m.cfg.userName = ""
+		m.cfg.userEmail = ""
+		m.watching = false
+		m.keptSessions = make(map[string]bool)
+	case hookFinishedMsg:
+		logHookFinished(m.cfg.logger, msg)
+		return m, nil
 	case apiSuccessResponseMsg:
 		m.active = msg.redirect
+		hookCmd = runEventHooks(m.cfg, msg.events)
+		historyCmd = snapshotNotes(m.cfg, eventRecords(msg.events)...)
+		activityCmd = recordActivity(m.cfg, msg.events)
+	case getRecordLoadedMsg:
+		historyCmd = snapshotNotes(m.cfg, msg.record)
+	case detailLoadedMsg:
+		historyCmd = snapshotNotes(m.cfg, msg.record)
+	case recordDeletedMsg:
+		hookCmd = runEventHooks(m.cfg, []hook.Event{msg.event})
+		activityCmd = recordActivity(m.cfg, []hook.Event{msg.event})
 	}
 
 	if m.active != nil {
 		m.active, cmd = m.active.Update(msg)
 	}
 
-	return m, cmd
+	return m, tea.Batch(cmd, hookCmd, historyCmd, activityCmd, watchCmd)
+}
+
+// pomodoroEventsCmd runs what follows the events of the pomodoro sessions
+// changed outside of any page.
+func (m mainModel) pomodoroEventsCmd(events []hook.Event) tea.Cmd {
+	return tea.Batch(
+		runEventHooks(m.cfg, events),
+		snapshotNotes(m.cfg, eventRecords(events)...),
+		recordActivity(m.cfg, events),
+	)
 }
 
 func (m mainModel) View() string {
 	if m.active == nil {
 		return "Loading..."
 	}
-	return m.active.View()
+
+	view := m.active.View()
+	if m.pomodoro == nil && m.prompt == nil && m.notice == "" {
+		return view
+	}
+
+	return m.statusView(view)
+}
+
+// statusView places the status line at the bottom of the screen, and the
+// prompt popup on top of the given page view.
+func (m mainModel) statusView(view string) string {
+	var status string
+	switch {
+	case m.notice != "":
+		status = lipgloss.NewStyle().
+			Width(m.width).
+			AlignHorizontal(lipgloss.Center).
+			Render(style.WarningStyle.Render(m.notice))
+	case m.pomodoro != nil:
+		status = m.pomodoro.view(m.width)
+	}
+
+	if h := lipgloss.Height(view); h < m.height-1 {
+		view += strings.Repeat("\n", m.height-1-h)
+	}
+	if status != "" {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, status)
+	}
+
+	if m.prompt != nil {
+		popup := m.prompt.View()
+		overlayX := lipgloss.Width(view)/2 - lipgloss.Width(popup)/2
+		overlayY := lipgloss.Height(view)/2 - lipgloss.Height(popup)/2
+		view = strview.PlaceOverlay(overlayX, overlayY, popup, view)
+	}
+
+	return view
 }
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/liuminhaw/yatijapp-tui/colors"
+	"github.com/liuminhaw/yatijapp-tui/internal/data"
+	"github.com/liuminhaw/yatijapp-tui/internal/datetime"
+	"github.com/liuminhaw/yatijapp-tui/internal/history"
+	"github.com/liuminhaw/yatijapp-tui/internal/hook"
+	"github.com/liuminhaw/yatijapp-tui/internal/style"
+	"github.com/liuminhaw/yatijapp-tui/pkg/linediff"
+)
+
+const (
+	noteHistoryWidth    = 76
+	noteHistoryRows     = 6
+	noteHistoryDiffRows = 12
+)
+
+// snapshotNotes saves the notes of the records as their latest revision in
+// the local note history.
+func snapshotNotes(cfg config, records ...yatijappRecord) tea.Cmd {
+	return func() tea.Msg {
+		for _, record := range records {
+			if record == nil || record.GetUUID() == "" {
+				continue
+			}
+
+			at := record.GetUpdatedAt()
+			if at.IsZero() {
+				at = time.Now()
+			}
+			if err := cfg.noteHistory.Save(record.GetUUID(), record.GetNote(), at); err != nil {
+				cfg.logger.Error(
+					err.Error(),
+					slog.String("action", "save note revision"),
+					slog.String("uuid", record.GetUUID()),
+				)
+			}
+		}
+		return nil
+	}
+}
+
+// eventRecords returns the records the events were about.
+func eventRecords(events []hook.Event) []yatijappRecord {
+	var records []yatijappRecord
+	for _, event := range events {
+		if record, ok := event.Record.(yatijappRecord); ok {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// restoreNote updates the record with the note, leaving every other field
+// as it is.
+func restoreNote(cfg config, record yatijappRecord, note string, src, redirect tea.Model) tea.Cmd {
+	d := recordRequestData{
+		uuid:   record.GetUUID(),
+		note:   nullNote{valid: true, note: note},
+		before: record,
+	}
+	msg := "Note restored"
+
+	switch record := record.(type) {
+	case data.Session:
+		d.actionUUID = record.ActionUUID
+		d.startsAt = record.StartsAt
+		d.endsAt = record.EndsAt
+		return updateSession(cfg.apiEndpoint, msg, d, src, redirect, cfg.authClient)
+	}
+
+	d.title = record.GetTitle()
+	d.description = record.GetDescription()
+	d.status = record.GetStatus()
+	if due, ok := record.GetDueDate(); ok {
+		d.dueDate = due.Format(datetime.APIDateLayout)
+	}
+
+	switch record.GetActualType() {
+	case data.RecordTypeTarget:
+		return updateTarget(cfg.apiEndpoint, msg, d, src, redirect, cfg.authClient)
+	case data.RecordTypeAction:
+		d.targetUUID = record.GetParentsUUID()[data.RecordTypeTarget]
+		return updateAction(cfg.apiEndpoint, msg, d, src, redirect, cfg.authClient)
+	default:
+		panic("unsupported record type in restoreNote")
+	}
+}
+
+// noteHistoryPopup lists the note revisions of a record, and shows the diff
+// from a base revision to the selected one. The base is the revision before
+// the selected one, unless one is marked.
+type noteHistoryPopup struct {
+	revisions []history.Revision // newest first
+	cursor    int
+	base      int // marked base revision, -1 for the previous revision
+	confirm   bool
+
+	diff    viewport.Model
+	restore func(note string) tea.Cmd
+}
+
+func newNoteHistoryPopup(revisions []history.Revision, restore func(string) tea.Cmd) noteHistoryPopup {
+	newest := make([]history.Revision, len(revisions))
+	for i, r := range revisions {
+		newest[len(revisions)-1-i] = r
+	}
+
+	p := noteHistoryPopup{
+		revisions: newest,
+		base:      -1,
+		diff:      viewport.New(noteHistoryWidth-4, noteHistoryDiffRows),
+		restore:   restore,
+	}
+	p.renderDiff()
+
+	return p
+}
+
+func (p noteHistoryPopup) Init() tea.Cmd {
+	return nil
+}
+
+func (p noteHistoryPopup) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return p, nil
+	}
+
+	if p.confirm {
+		switch keyMsg.String() {
+		case "y", "Y":
+			return p, p.restore(p.revisions[p.cursor].Note)
+		case "n", "N", "esc":
+			p.confirm = false
+		}
+		return p, nil
+	}
+
+	switch keyMsg.String() {
+	case "esc", "q", "H":
+		return p, cancelPopupCmd
+	case "up", "k":
+		if p.cursor > 0 {
+			p.cursor--
+			p.renderDiff()
+		}
+	case "down", "j":
+		if p.cursor < len(p.revisions)-1 {
+			p.cursor++
+			p.renderDiff()
+		}
+	case " ":
+		if p.base == p.cursor {
+			p.base = -1
+		} else {
+			p.base = p.cursor
+		}
+		p.renderDiff()
+	case "r":
+		if len(p.revisions) > 0 && p.cursor > 0 {
+			p.confirm = true
+		}
+	case "pgdown", "ctrl+d":
+		p.diff.HalfPageDown()
+	case "pgup", "ctrl+u":
+		p.diff.HalfPageUp()
+	}
+
+	return p, nil
+}
+
+// baseIndex returns the index of the revision the selected one is compared
+// to, -1 for the empty note before the first revision.
+func (p noteHistoryPopup) baseIndex() int {
+	if p.base >= 0 && p.base != p.cursor {
+		return p.base
+	}
+	if p.cursor+1 < len(p.revisions) {
+		return p.cursor + 1
+	}
+	return -1
+}
+
+func (p *noteHistoryPopup) renderDiff() {
+	if len(p.revisions) == 0 {
+		p.diff.SetContent(style.Document.NormalDim.Render("No revisions saved yet"))
+		return
+	}
+
+	var base string
+	if i := p.baseIndex(); i >= 0 {
+		base = p.revisions[i].Note
+	}
+
+	hunks := linediff.Unified(base, p.revisions[p.cursor].Note, 3)
+	if len(hunks) == 0 {
+		p.diff.SetContent(style.Document.NormalDim.Render("No changes"))
+		return
+	}
+
+	truncate := lipgloss.NewStyle().MaxWidth(p.diff.Width)
+	headerStyle := lipgloss.NewStyle().Foreground(colors.Secondary)
+	insertStyle := lipgloss.NewStyle().Foreground(colors.Success)
+	deleteStyle := lipgloss.NewStyle().Foreground(colors.Danger)
+
+	var lines []string
+	for _, hunk := range hunks {
+		lines = append(lines, truncate.Render(headerStyle.Render(hunk.Header())))
+		for _, line := range hunk.Lines {
+			var rendered string
+			switch line.Op {
+			case linediff.Insert:
+				rendered = insertStyle.Render(line.String())
+			case linediff.Delete:
+				rendered = deleteStyle.Render(line.String())
+			default:
+				rendered = style.Document.NormalDim.Render(line.String())
+			}
+			lines = append(lines, truncate.Render(rendered))
+		}
+	}
+
+	p.diff.SetContent(strings.Join(lines, "\n"))
+	p.diff.GotoTop()
+}
+
+func (p noteHistoryPopup) View() string {
+	f := datetime.Display()
+
+	var b strings.Builder
+	b.WriteString(style.Document.Secondary.Bold(true).Render("Note history") + "\n\n")
+
+	if len(p.revisions) == 0 {
+		b.WriteString(style.Document.NormalDim.Render("No revisions saved yet") + "\n")
+	}
+
+	start := max(0, min(p.cursor-noteHistoryRows/2, len(p.revisions)-noteHistoryRows))
+	end := min(start+noteHistoryRows, len(p.revisions))
+	for i := start; i < end; i++ {
+		r := p.revisions[i]
+		marker := "  "
+		switch {
+		case i == p.cursor:
+			marker = "› "
+		case i == p.baseIndex():
+			marker = "◇ "
+		}
+
+		label := fmt.Sprintf("%d lines", strings.Count(r.Note, "\n")+1)
+		if r.Note == "" {
+			label = "empty"
+		}
+		if i == 0 {
+			label += " · latest"
+		}
+
+		row := marker + f.DateTime(r.At) + "  " + label
+		if i == p.cursor {
+			b.WriteString(style.Document.Primary.Bold(true).Render(row) + "\n")
+		} else {
+			b.WriteString(style.Document.Normal.Render(row) + "\n")
+		}
+	}
+
+	if len(p.revisions) > 0 {
+		from := "empty"
+		if i := p.baseIndex(); i >= 0 {
+			from = f.DateTime(p.revisions[i].At)
+		}
+		b.WriteString("\n" + style.Document.NormalDim.Render(
+			"Diff "+from+" → "+f.DateTime(p.revisions[p.cursor].At),
+		) + "\n")
+		b.WriteString(style.BorderStyle["dimmed"].Render(p.diff.View()) + "\n")
+	}
+
+	if p.confirm {
+		b.WriteString(style.Document.Highlight.Render(
+			"Restore note from " + f.DateTime(p.revisions[p.cursor].At) + "? [y]es [n]o",
+		))
+	} else {
+		b.WriteString(style.HelperView([]style.HelperContent{
+			{Key: "↑/↓", Action: "revision"},
+			{Key: "Space", Action: "mark base"},
+			{Key: "PgUp/PgDn", Action: "scroll"},
+			{Key: "r", Action: "restore"},
+			{Key: "Esc", Action: "close"},
+		}, noteHistoryWidth-4))
+	}
+
+	return style.BorderStyle["highlighted"].
+		Width(noteHistoryWidth).
+		Padding(0, 1).
+		Render(b.String())
+}
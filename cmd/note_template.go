@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/liuminhaw/yatijapp-tui/internal/data"
+	"github.com/liuminhaw/yatijapp-tui/internal/model"
+	"github.com/liuminhaw/yatijapp-tui/internal/notetemplate"
+)
+
+// noteTemplatesMax is the number of templates offered, picked by the keys 1
+// to 9.
+const noteTemplatesMax = 9
+
+type noteTemplateSelectedMsg struct {
+	template *notetemplate.Template // nil for a blank note
+	inline   bool
+}
+
+func noteTemplateSelectedCmd(template *notetemplate.Template, inline bool) tea.Cmd {
+	return func() tea.Msg {
+		return noteTemplateSelectedMsg{template: template, inline: inline}
+	}
+}
+
+// offerNoteTemplates shows the templates of the record type to start the note
+// from, the note is opened right away when there are none.
+func (p recordConfigPage) offerNoteTemplates(inline bool) (recordConfigPage, tea.Cmd) {
+	note, ok := p.fields[p.focused].(*model.NoteModel)
+	if !ok {
+		return p, nil
+	}
+
+	templates, err := notetemplate.List(p.cfg.templatesDir, string(p.recordType))
+	if err != nil {
+		p.cfg.logger.Error(err.Error(), slog.String("action", "list note templates"))
+	}
+	if len(templates) == 0 {
+		return p, note.Edit(inline)
+	}
+	if len(templates) > noteTemplatesMax {
+		templates = templates[:noteTemplatesMax]
+	}
+
+	options := make([]model.AlertOption, 0, len(templates)+1)
+	for i := range templates {
+		options = append(options, model.AlertOption{
+			Key:   strconv.Itoa(i + 1),
+			Label: templates[i].Name,
+			Cmd:   noteTemplateSelectedCmd(&templates[i], inline),
+		})
+	}
+	options = append(options, model.AlertOption{
+		Key:   "b",
+		Label: "blank",
+		Cmd:   noteTemplateSelectedCmd(nil, inline),
+	})
+
+	p.templates = model.NewOptionsAlert(
+		"Note Template",
+		[]string{fmt.Sprintf("Start the %s note from a template?", strings.ToLower(string(p.recordType)))},
+		nil,
+		50,
+		options,
+	)
+	return p, nil
+}
+
+// applyNoteTemplate fills the focused note with the rendered template, and
+// opens it in the editor.
+func (p recordConfigPage) applyNoteTemplate(msg noteTemplateSelectedMsg) (recordConfigPage, tea.Cmd) {
+	p.templates = nil
+
+	note, ok := p.fields[p.focused].(*model.NoteModel)
+	if !ok {
+		return p, nil
+	}
+
+	if msg.template != nil {
+		content, err := msg.template.Render(p.templateVars())
+		if err != nil {
+			p.err = fmt.Errorf("failed to render template %s: %w", msg.template.Name, err)
+			return p, nil
+		}
+		if err := note.SetValues(content); err != nil {
+			p.err = fmt.Errorf("failed to apply template %s: %w", msg.template.Name, err)
+			return p, nil
+		}
+	}
+
+	return p, note.Edit(msg.inline)
+}
+
+// templateVars returns the template values from the fields of the form.
+func (p recordConfigPage) templateVars() notetemplate.Vars {
+	vars := notetemplate.Vars{User: p.cfg.userName, Now: time.Now()}
+	if i, ok := p.selectorFields[data.RecordTypeTarget]; ok {
+		vars.Target = p.fields[i].Value()
+	}
+	if i, ok := p.selectorFields[data.RecordTypeAction]; ok {
+		vars.Action = p.fields[i].Value()
+	}
+
+	switch p.recordType {
+	case data.RecordTypeTarget:
+		vars.Title = p.fields[0].Value()
+		vars.Target = vars.Title
+	case data.RecordTypeAction:
+		vars.Title = p.fields[0].Value()
+		vars.Action = vars.Title
+	case data.RecordTypeSession:
+		vars.Title = vars.Action
+	}
+
+	return vars
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"log/slog"
 	"slices"
 	"strconv"
@@ -23,6 +24,8 @@ type cmdAction int
 const (
 	cmdCreate cmdAction = iota
 	cmdUpdate
+	cmdLog   // create a session which has already ended
+	cmdSplit // split a session in two
 )
 
 type (
@@ -49,7 +52,10 @@ type (
 	}
 
 	cancelPopupMsg       struct{}
-	obtainPreferencesMsg struct{ preferences data.Preferences }
+	obtainPreferencesMsg struct{ preferences *data.Preferences }
+	obtainUserMsg        struct{ user data.User }
+	loginUserLoadedMsg   struct{ user data.User }
+	signedOutMsg         struct{ redirect tea.Model }
 )
 
 var (
@@ -101,12 +107,20 @@ func selectorSelectedCmd(
 	}
 }
 
-func obtainPreferencesCmd(preferences data.Preferences) tea.Cmd {
+// obtainPreferencesCmd hands the preferences loaded by the menu to the main
+// model, the pointer being shared by all the pages.
+func obtainPreferencesCmd(preferences *data.Preferences) tea.Cmd {
 	return func() tea.Msg {
 		return obtainPreferencesMsg{preferences: preferences}
 	}
 }
 
+func obtainUserCmd(user data.User) tea.Cmd {
+	return func() tea.Msg {
+		return obtainUserMsg{user: user}
+	}
+}
+
 type sourceInfo struct {
 	title string
 	uuid  string
@@ -124,6 +138,12 @@ type recordsSelection struct {
 	offset   int
 	query    map[string]string
 	metadata data.Metadata
+
+	// match optionally keeps the loaded records matching the search terms
+	// the API does not handle, loaded counts the records of the page before
+	// they are matched
+	match  func(yatijappRecord) bool
+	loaded int
 }
 
 func newRecordsSelection(pageSize int) recordsSelection {
@@ -146,17 +166,30 @@ func (rs *recordsSelection) view() string {
 		nextArrow = lipgloss.NewStyle().Foreground(colors.Text).Render("▸")
 	}
 
-	return lipgloss.JoinHorizontal(
+	view := lipgloss.JoinHorizontal(
 		lipgloss.Top,
 		prevArrow,
 		rs.p.View(),
 		nextArrow,
 	)
+	if rs.match != nil && len(rs.records) < rs.loaded {
+		// pages are filtered once loaded, they can come out short or empty
+		view += style.Document.NormalDim.Render(
+			fmt.Sprintf("  %d of %d shown on this page", len(rs.records), rs.loaded),
+		)
+	}
+	return view
 }
 
 func (rs *recordsSelection) setRecords(msg allRecordsLoadedMsg, logger *slog.Logger) {
 	rs.metadata = msg.metadata
 	rs.records = msg.records
+	rs.loaded = len(msg.records)
+	if rs.match != nil {
+		rs.records = slices.DeleteFunc(slices.Clone(rs.records), func(r yatijappRecord) bool {
+			return !rs.match(r)
+		})
+	}
 
 	rs.p.SetTotalPages(len(rs.records))
 
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/liuminhaw/yatijapp-tui/internal/authclient"
+	"github.com/liuminhaw/yatijapp-tui/internal/data"
+	"github.com/liuminhaw/yatijapp-tui/internal/datetime"
+	"github.com/liuminhaw/yatijapp-tui/internal/hook"
+	"github.com/liuminhaw/yatijapp-tui/internal/model"
+	"github.com/liuminhaw/yatijapp-tui/internal/style"
+)
+
+var pomodoroOptions = []string{"off", "on"}
+
+type pomodoroConfig struct {
+	work  time.Duration
+	rest  time.Duration
+	notes bool // append a summary line to the session notes per interval
+}
+
+type pomodoroPhase int
+
+const (
+	pomodoroWork pomodoroPhase = iota
+	pomodoroBreak
+	pomodoroWaiting // break is over, waiting for the user to start the next round
+	pomodoroEnding  // work interval is over, waiting for the session to be ended
+)
+
+type (
+	pomodoroStartedMsg struct {
+		session data.Session
+		success *apiSuccessResponseMsg // nil for the rounds started from the prompt
+		events  []hook.Event
+	}
+	pomodoroTickMsg struct {
+		id int
+	}
+	pomodoroIntervalEndedMsg struct {
+		id      int
+		stopped bool // session was already ended outside of the pomodoro
+		events  []hook.Event
+	}
+	pomodoroErrMsg struct {
+		id  int
+		err error
+	}
+	pomodoroContinueMsg struct{}
+	pomodoroStopMsg     struct{}
+)
+
+// pomodoro keeps track of a running work/break cycle. Each work interval is
+// a session of its own, which is ended automatically when the interval is
+// over.
+type pomodoro struct {
+	id     int
+	cfg    pomodoroConfig
+	phase  pomodoroPhase
+	round  int
+	endsAt time.Time
+
+	session data.Session
+}
+
+func newPomodoro(id int, cfg pomodoroConfig, session data.Session, round int) *pomodoro {
+	return &pomodoro{
+		id:      id,
+		cfg:     cfg,
+		phase:   pomodoroWork,
+		round:   round,
+		endsAt:  session.StartsAt.Add(cfg.work),
+		session: session,
+	}
+}
+
+func (p *pomodoro) tick() tea.Cmd {
+	id := p.id
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return pomodoroTickMsg{id: id}
+	})
+}
+
+func (p *pomodoro) remaining() time.Duration {
+	return max(time.Until(p.endsAt), 0).Round(time.Second)
+}
+
+// startBreak moves the pomodoro into its break interval.
+func (p *pomodoro) startBreak() {
+	p.phase = pomodoroBreak
+	p.endsAt = time.Now().Add(p.cfg.rest)
+}
+
+func (p *pomodoro) view(width int) string {
+	var status string
+	switch p.phase {
+	case pomodoroWork:
+		status = fmt.Sprintf(
+			"Pomodoro #%d · work · %s left · %s",
+			p.round, formatCountdown(p.remaining()), p.session.ActionTitle,
+		)
+	case pomodoroEnding:
+		status = fmt.Sprintf("Pomodoro #%d · ending session...", p.round)
+	case pomodoroBreak:
+		status = fmt.Sprintf(
+			"Pomodoro #%d · break · %s left", p.round, formatCountdown(p.remaining()),
+		)
+	case pomodoroWaiting:
+		status = fmt.Sprintf("Pomodoro #%d · break is over", p.round)
+	}
+
+	return lipgloss.NewStyle().
+		Width(width).
+		AlignHorizontal(lipgloss.Center).
+		Render(style.WarningStyle.Render(status))
+}
+
+func formatCountdown(d time.Duration) string {
+	minutes := int(d / time.Minute)
+	seconds := int((d % time.Minute) / time.Second)
+	return fmt.Sprintf("%02d:%02d", minutes, seconds)
+}
+
+func (p *pomodoro) nextPrompt() tea.Model {
+	return model.NewAlert(
+		"Break is over",
+		"confirmation",
+		[]string{
+			fmt.Sprintf("Start pomodoro #%d", p.round+1),
+			"for \"" + p.session.ActionTitle + "\"?",
+		},
+		[]string{""},
+		60,
+		map[string]tea.Cmd{
+			"confirm": func() tea.Msg { return pomodoroContinueMsg{} },
+			"cancel":  func() tea.Msg { return pomodoroStopMsg{} },
+		},
+	)
+}
+
+// createPomodoroSession starts a new session which is tracked by a pomodoro
+// once created. src and redirect are nil when the session is not started
+// from a page.
+func createPomodoroSession(
+	serverURL, actionTitle string,
+	d recordRequestData,
+	src, redirect tea.Model,
+	client *authclient.AuthClient,
+) tea.Cmd {
+	return func() tea.Msg {
+		request := d.sessionRequestBody()
+		session, err := request.Create(serverURL, client)
+		if err != nil {
+			return err
+		}
+		if session.ActionTitle == "" {
+			session.ActionTitle = actionTitle
+		}
+
+		msg := pomodoroStartedMsg{
+			session: session,
+			events:  []hook.Event{recordEvent(hook.SessionStarted, nil, session)},
+		}
+		if src != nil {
+			msg.success = &apiSuccessResponseMsg{
+				msg:      "New pomodoro session started",
+				source:   src,
+				redirect: redirect,
+				events:   msg.events,
+			}
+		}
+		return msg
+	}
+}
+
+// nextPomodoroSession starts the session for the next pomodoro round.
+func nextPomodoroSession(
+	serverURL string,
+	p *pomodoro,
+	client *authclient.AuthClient,
+) tea.Cmd {
+	d := recordRequestData{actionUUID: p.session.ActionUUID}
+	return createPomodoroSession(serverURL, p.session.ActionTitle, d, nil, nil, client)
+}
+
+// endPomodoroInterval ends the session of the finished work interval,
+// appending an interval summary to the session notes when enabled.
+func endPomodoroInterval(
+	serverURL string,
+	p *pomodoro,
+	client *authclient.AuthClient,
+) tea.Cmd {
+	id := p.id
+	uuid := p.session.UUID
+	round := p.round
+	notes := p.cfg.notes
+
+	return func() tea.Msg {
+		session, err := data.GetSession(serverURL, uuid, client)
+		if err != nil {
+			return pomodoroErrMsg{id: id, err: err}
+		}
+		if session.EndsAt.Valid {
+			return pomodoroIntervalEndedMsg{id: id, stopped: true}
+		}
+
+		endsAt := time.Now()
+		request := data.SessionRequestBody{
+			ActionUUID: session.ActionUUID,
+			EndsAt:     sql.NullTime{Valid: true, Time: endsAt},
+		}
+		if notes {
+			note := pomodoroNote(session.Notes, round, session.StartsAt, endsAt)
+			request.Notes = &note
+		}
+
+		ended, err := request.Update(serverURL, uuid, client)
+		if err != nil {
+			return pomodoroErrMsg{id: id, err: err}
+		}
+
+		return pomodoroIntervalEndedMsg{id: id, events: updatedEvents(session, ended)}
+	}
+}
+
+func pomodoroNote(notes string, round int, startsAt, endsAt time.Time) string {
+	line := fmt.Sprintf(
+		"- Pomodoro #%d: %s → %s (%s)",
+		round,
+		datetime.Display().Clock(startsAt),
+		datetime.Display().Clock(endsAt),
+		endsAt.Sub(startsAt).Round(time.Minute),
+	)
+
+	notes = strings.TrimRight(notes, "\n")
+	if notes == "" {
+		return line + "\n"
+	}
+	return notes + "\n" + line + "\n"
+}
+
+// shortDuration formats durations like 25m0s as 25m.
+func shortDuration(d time.Duration) string {
+	s := d.String()
+	s = strings.TrimSuffix(s, "0s")
+	s = strings.TrimSuffix(s, "0m")
+	if s == "" {
+		return "0s"
+	}
+	return s
+}
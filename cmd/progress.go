@@ -0,0 +1,406 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/liuminhaw/yatijapp-tui/internal/authclient"
+	"github.com/liuminhaw/yatijapp-tui/internal/data"
+	"github.com/liuminhaw/yatijapp-tui/internal/datetime"
+	"github.com/liuminhaw/yatijapp-tui/internal/model"
+	"github.com/liuminhaw/yatijapp-tui/internal/progress"
+	"github.com/liuminhaw/yatijapp-tui/internal/style"
+	"github.com/liuminhaw/yatijapp-tui/pkg/braille"
+)
+
+const (
+	progressPageSize    = 100
+	progressMaxPages    = 20
+	progressChartHeight = 10
+	progressBarWidth    = 40
+)
+
+type targetProgressMsg struct {
+	seq       int // load of the list the count was asked for
+	uuid      string
+	completed int64
+	err       error
+}
+
+// targetsProgress holds the completed actions of the targets of a list. The
+// counts are asked for the rows shown, and dropped when the list reloads.
+type targetsProgress struct {
+	seq       int
+	completed map[string]int64
+	pending   map[string]bool
+}
+
+func (p *targetsProgress) reset() {
+	p.seq++
+	p.completed = make(map[string]int64)
+	p.pending = make(map[string]bool)
+}
+
+// load counts the completed actions of the targets not counted yet, asking
+// for a single record to read the total from the metadata.
+func (p targetsProgress) load(
+	serverURL string,
+	targets []yatijappRecord,
+	client *authclient.AuthClient,
+) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, target := range targets {
+		uuid := target.GetUUID()
+		if _, ok := p.completed[uuid]; ok || p.pending == nil || p.pending[uuid] {
+			continue
+		}
+		p.pending[uuid] = true
+
+		seq := p.seq
+		cmds = append(cmds, func() tea.Msg {
+			resp, err := data.ListActions(data.ListRequestInfo{
+				ServerURL:    serverURL,
+				SrcUUID:      uuid,
+				QueryStrings: map[string]string{"status": "completed", "page_size": "1"},
+			}, client)
+			if err != nil {
+				return targetProgressMsg{seq: seq, uuid: uuid, err: err}
+			}
+			return targetProgressMsg{seq: seq, uuid: uuid, completed: int64(resp.Metadata.TotalRecords)}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// loaded keeps the count of the message, unless the list reloaded since.
+// Failed counts are not asked for again until the list reloads.
+func (p targetsProgress) loaded(msg targetProgressMsg) {
+	if msg.seq != p.seq {
+		return
+	}
+	if msg.err == nil {
+		delete(p.pending, msg.uuid)
+		p.completed[msg.uuid] = msg.completed
+	}
+}
+
+// completedActions returns the completed actions of the target, when known.
+func (p targetsProgress) completedActions(uuid string) (int64, bool) {
+	completed, ok := p.completed[uuid]
+	return completed, ok
+}
+
+type targetActionsLoadedMsg struct {
+	actions []progress.Action
+	err     error
+}
+
+// loadTargetActions pages through all the actions of the target, whatever
+// their status.
+func loadTargetActions(serverURL, uuid string, client *authclient.AuthClient) tea.Cmd {
+	return func() tea.Msg {
+		var actions []progress.Action
+		for page := 1; page <= progressMaxPages; page++ {
+			resp, err := data.ListActions(data.ListRequestInfo{
+				ServerURL: serverURL,
+				SrcUUID:   uuid,
+				QueryStrings: map[string]string{
+					"status":    strings.Join(model.StatusOptions, ","),
+					"page":      strconv.Itoa(page),
+					"page_size": strconv.Itoa(progressPageSize),
+				},
+			}, client)
+			if err != nil {
+				return targetActionsLoadedMsg{err: err}
+			}
+
+			for _, action := range resp.Actions {
+				actions = append(actions, progress.Action{
+					Created: action.CreatedAt,
+					Updated: action.UpdatedAt,
+					Status:  action.Status,
+				})
+			}
+			if len(resp.Actions) < progressPageSize || page >= resp.Metadata.LastPage {
+				break
+			}
+		}
+		return targetActionsLoadedMsg{actions: actions}
+	}
+}
+
+// progressPage shows the completed actions of a target and charts its
+// progress day by day, either as a burnup or a burndown.
+type progressPage struct {
+	cfg   config
+	uuid  string
+	title string
+
+	counts   progress.Counts
+	days     []progress.Day
+	burndown bool
+
+	spinner spinner.Model
+	loading bool
+	err     error
+
+	width  int
+	height int
+
+	prev tea.Model
+}
+
+func newProgressPage(
+	cfg config,
+	termSize style.ViewSize,
+	uuid, title string,
+	prev tea.Model,
+) progressPage {
+	return progressPage{
+		cfg:     cfg,
+		uuid:    uuid,
+		title:   title,
+		spinner: spinner.New(spinner.WithSpinner(spinner.Line)),
+		loading: true,
+		width:   termSize.Width,
+		height:  termSize.Height,
+		prev:    prev,
+	}
+}
+
+func (p progressPage) Init() tea.Cmd {
+	return tea.Batch(p.spinner.Tick, loadTargetActions(p.cfg.apiEndpoint, p.uuid, p.cfg.authClient))
+}
+
+func (p progressPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.width = msg.Width
+		p.height = msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q":
+			return p, tea.Quit
+		case "<", "esc":
+			return p, switchToPreviousCmd(p.prev)
+		case "m":
+			return p, switchToMenuCmd
+		case "b":
+			p.burndown = !p.burndown
+		case "r", "ctrl+r":
+			if p.loading {
+				return p, nil
+			}
+			p.loading = true
+			p.err = nil
+			return p, tea.Batch(
+				p.spinner.Tick,
+				loadTargetActions(p.cfg.apiEndpoint, p.uuid, p.cfg.authClient),
+			)
+		}
+	case targetActionsLoadedMsg:
+		p.loading = false
+		if msg.err != nil {
+			p.cfg.logger.Error(
+				msg.err.Error(),
+				slog.String("action", "load target actions"),
+				slog.String("uuid", p.uuid),
+			)
+			var ue data.UnauthorizedApiDataErr
+			if errors.As(msg.err, &ue) {
+				return p, switchToMenuCmd
+			}
+			p.err = msg.err
+			return p, nil
+		}
+		p.counts = progress.Count(msg.actions)
+		p.days = progress.Days(msg.actions, datetime.Now())
+	case spinner.TickMsg:
+		if !p.loading {
+			return p, nil
+		}
+		var cmd tea.Cmd
+		p.spinner, cmd = p.spinner.Update(msg)
+		return p, cmd
+	}
+
+	return p, nil
+}
+
+func (p progressPage) View() string {
+	title := style.TitleBarView([]string{"Progress", p.title}, viewWidth, false)
+
+	if p.loading && p.days == nil {
+		container := style.LoadingView(
+			&p.spinner,
+			"Loading actions",
+			style.ViewSize{Width: viewWidth, Height: 10},
+		)
+		container = lipgloss.JoinVertical(lipgloss.Center, title, container)
+		return style.ContainerStyle(p.width, container, 5).Render(container)
+	}
+
+	if p.err != nil && p.days == nil {
+		return style.FullPageErrorView(
+			title,
+			p.width,
+			style.ViewSize{Width: viewWidth, Height: 10},
+			errors.New(apiErrorText(p.err)),
+			[]style.HelperContent{{Key: "r", Action: "retry"}, {Key: "<", Action: "back"}},
+		)
+	}
+
+	var status string
+	switch {
+	case p.loading:
+		p.spinner.Style = style.Document.Highlight
+		status = p.spinner.View() + style.Document.NormalDim.Render(" updating actions...")
+	case p.err != nil:
+		status = style.ErrorStyle.Render("Update failed: " + apiErrorText(p.err))
+	}
+	statusView := lipgloss.NewStyle().Width(viewWidth).Padding(0, 1).Render(status)
+
+	chart := style.MsgView(
+		style.ViewSize{Width: viewWidth, Height: progressChartHeight + 3},
+		"No actions under this target yet",
+	)
+	if len(p.days) > 0 {
+		chart = p.chartView()
+	}
+
+	chartName := "burndown"
+	if p.burndown {
+		chartName = "burnup"
+	}
+	helperView := style.HelperView([]style.HelperContent{
+		{Key: "b", Action: chartName},
+		{Key: "r", Action: "update"},
+		{Key: "<", Action: "back"},
+		{Key: "m", Action: "menu"},
+		{Key: "q", Action: "quit"},
+	}, viewWidth)
+
+	container := lipgloss.JoinVertical(
+		lipgloss.Center,
+		title,
+		statusView,
+		p.summaryView(),
+		"",
+		chart,
+		"",
+		helperView,
+	)
+
+	return style.ContainerStyle(p.width, container, 5).Render(container)
+}
+
+// summaryView shows the completed actions out of all the actions, and the
+// number of actions of each other status.
+func (p progressPage) summaryView() string {
+	c := p.counts
+	percent := 0
+	if c.Total > 0 {
+		percent = 100 * c.Completed / c.Total
+	}
+
+	lines := []string{
+		style.ProgressBarView(int64(c.Completed), int64(c.Total), progressBarWidth) + "  " +
+			statsFigure("Completed", fmt.Sprintf("%d/%d", c.Completed, c.Total)) + "  " +
+			style.Document.Highlight.Render(fmt.Sprintf("%d%%", percent)),
+		strings.Join([]string{
+			statsFigure("Queued", strconv.Itoa(c.Queued)),
+			statsFigure("In progress", strconv.Itoa(c.InProgress)),
+			statsFigure("Canceled", strconv.Itoa(c.Canceled)),
+		}, "  "),
+	}
+	return lipgloss.NewStyle().Width(viewWidth).Padding(0, 1).Render(strings.Join(lines, "\n"))
+}
+
+// chartView plots the days with the scale on the left and the first and
+// last dates below.
+func (p progressPage) chartView() string {
+	top := 1
+	for _, d := range p.days {
+		if p.burndown {
+			top = max(top, d.Remaining())
+		} else {
+			top = max(top, d.Scope)
+		}
+	}
+
+	labelWidth := len(strconv.Itoa(top))
+	width := viewWidth - 2 - labelWidth - 2
+	canvas := braille.New(width, progressChartHeight)
+
+	var styles []lipgloss.Style
+	var legend []string
+	series := func(values []float64, name string, s lipgloss.Style) {
+		canvas.Plot(values, float64(top), len(styles))
+		styles = append(styles, s)
+		legend = append(legend, s.Render("━━")+" "+style.Document.NormalDim.Render(name))
+	}
+
+	remaining := make([]float64, len(p.days))
+	scope := make([]float64, len(p.days))
+	completed := make([]float64, len(p.days))
+	for i, d := range p.days {
+		remaining[i] = float64(d.Remaining())
+		scope[i] = float64(d.Scope)
+		completed[i] = float64(d.Completed)
+	}
+
+	name := "Burnup"
+	if p.burndown {
+		name = "Burndown"
+		series(remaining, "Remaining", style.Document.Primary)
+	} else {
+		series(scope, "Scope", style.Document.Secondary)
+		series(completed, "Completed", style.StatusTextStyle("completed"))
+	}
+
+	gap, remain, ok := style.CalculateGap(
+		viewWidth-2, name, strings.Join(legend, "  "),
+	)
+	if !ok {
+		gap = 1
+	}
+	lines := []string{
+		style.Document.Normal.Render(name) + strings.Repeat(" ", gap+remain) + strings.Join(legend, "  "),
+	}
+
+	rows := canvas.Lines(func(s string, i int) string {
+		if i < 0 {
+			return s
+		}
+		return styles[i].Render(s)
+	})
+	for i, row := range rows {
+		var label string
+		switch i {
+		case 0:
+			label = strconv.Itoa(top)
+		case len(rows) - 1:
+			label = "0"
+		}
+		lines = append(lines, style.Document.NormalDim.Render(fmt.Sprintf("%*s ┤", labelWidth, label))+row)
+	}
+
+	f := datetime.Display()
+	first, last := f.Date(p.days[0].Date), f.Date(p.days[len(p.days)-1].Date)
+	dates := first
+	if len(p.days) > 1 {
+		dates += strings.Repeat(" ", max(1, width-len(first)-len(last))) + last
+	}
+	lines = append(lines,
+		style.Document.NormalDim.Render(strings.Repeat(" ", labelWidth+1)+"└"+strings.Repeat("─", width)),
+		style.Document.NormalDim.Render(strings.Repeat(" ", labelWidth+2)+dates),
+	)
+
+	return lipgloss.NewStyle().Width(viewWidth).Padding(0, 1).Render(strings.Join(lines, "\n"))
+}
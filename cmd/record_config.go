@@ -14,6 +14,7 @@ import (
 	"github.com/liuminhaw/yatijapp-tui/internal/authclient"
 	"github.com/liuminhaw/yatijapp-tui/internal/components"
 	"github.com/liuminhaw/yatijapp-tui/internal/data"
+	"github.com/liuminhaw/yatijapp-tui/internal/datetime"
 	"github.com/liuminhaw/yatijapp-tui/internal/model"
 	"github.com/liuminhaw/yatijapp-tui/internal/style"
 	"github.com/liuminhaw/yatijapp-tui/internal/validator"
@@ -63,6 +64,22 @@ type recordConfigPage struct {
 
 	selectorFields map[data.RecordType]int
 	selector       tea.Model
+
+	// dateFields maps the index of date fields to whether the field also
+	// takes a time of day, these fields can be set with the date picker.
+	dateFields map[int]bool
+	datePicker tea.Model
+
+	// editor is the built-in markdown editor of the focused field
+	editor tea.Model
+	// templates offers the note templates the first time the note is opened
+	templates tea.Model
+
+	// overlap keeps the session waiting for the user to decide how to deal
+	// with the sessions it overlaps.
+	overlap      tea.Model
+	overlapData  recordRequestData
+	overlapTrims []data.Session
 }
 
 func newRecordConfigPage(
@@ -108,7 +125,7 @@ func newRecordConfigPage(
 
 		dueDate, dueDateValid := record.GetDueDate()
 		if dueDateValid {
-			due.SetValues(dueDate.Format("2006-01-02"))
+			due.SetValues(datetime.Display().DueDate(dueDate))
 		}
 		description.SetValues(record.GetDescription())
 
@@ -137,6 +154,7 @@ func newRecordConfigPage(
 
 		uuid = record.GetUUID()
 	}
+	note.OfferTemplates = note.Value() == ""
 	focusables = append(focusables, name, due, description, status, note)
 
 	var focusCache int
@@ -165,6 +183,7 @@ func newRecordConfigPage(
 		fields:         focusables,
 		hiddenFields:   hiddens,
 		selectorFields: selectorFields,
+		dateFields:     map[int]bool{1: false},
 		width:          size.Width,
 		height:         size.Height,
 		prev:           prev,
@@ -226,6 +245,7 @@ func newSessionConfigPage(
 	focusables := []Focusable{}
 	hiddens := make(map[string]string)
 	selectorFields := make(map[data.RecordType]int)
+	dateFields := make(map[int]bool)
 
 	note := model.NewNoteModel()
 
@@ -238,6 +258,8 @@ func newSessionConfigPage(
 	startsAt := timeInput(formWidth, false)
 	endsAt := timeInput(formWidth, false)
 
+	pomodoro := model.NewRadioModel(pomodoroOptions, 20)
+
 	var uuid string
 	recordAction := cmdCreate
 	focused := 0
@@ -269,22 +291,25 @@ func newSessionConfigPage(
 			recordAction = cmdUpdate
 
 			session := record.(data.Session)
-			startsAt.SetValues(session.StartsAt.Format("2006-01-02 15:04:05"))
+			startsAt.SetValues(datetime.Display().DateTime(session.StartsAt))
 			if session.EndsAt.Valid {
-				endsAt.SetValues(session.EndsAt.Time.Format("2006-01-02 15:04:05"))
+				endsAt.SetValues(datetime.Display().DateTime(session.EndsAt.Time))
 			}
 
 			focusables = append(focusables, parentTarget, parentAction, startsAt, endsAt, note)
 			focused = 2
+			dateFields[2] = true
+			dateFields[3] = true
 		} else {
-			focusables = append(focusables, parentTarget, parentAction, note)
+			focusables = append(focusables, parentTarget, parentAction, note, pomodoro)
 			focused = 0
 		}
 
 	} else {
-		focusables = append(focusables, parentTarget, parentAction, note)
+		focusables = append(focusables, parentTarget, parentAction, note, pomodoro)
 		focused = 0
 	}
+	note.OfferTemplates = note.Value() == ""
 	focusables[focused].Focus()
 
 	selectorFields[data.RecordTypeTarget] = 0
@@ -301,6 +326,7 @@ func newSessionConfigPage(
 		focused:        focused,
 		hiddenFields:   hiddens,
 		selectorFields: selectorFields,
+		dateFields:     dateFields,
 		width:          size.Width,
 		height:         size.Height,
 		prev:           prev,
@@ -311,6 +337,132 @@ func newSessionConfigPage(
 	}, nil
 }
 
+// newSessionLogPage creates the form logging past work as a completed
+// session, timed by its duration and end time instead of both timestamps.
+func newSessionLogPage(
+	cfg config,
+	size style.ViewSize,
+	record yatijappRecord,
+	prev tea.Model,
+) (recordConfigPage, error) {
+	hiddens := make(map[string]string)
+
+	parentTarget := components.NewText("", showSelectorMsg{selection: data.RecordTypeTarget})
+	parentTarget.ValidateFunc = validator.ValidateRequired("target is required")
+
+	parentAction := components.NewText("", showSelectorMsg{selection: data.RecordTypeAction})
+	parentAction.ValidateFunc = validator.ValidateRequired("action is required")
+
+	duration := durationInput(formWidth, false)
+	endsAt := timeInput(formWidth, false)
+	note := model.NewNoteModel()
+
+	focused := 0
+	if record != nil {
+		if err := parentTarget.SetValues(record.GetParentsTitle()[data.RecordTypeTarget]); err != nil {
+			return recordConfigPage{}, internalErrorMsg{
+				msg: "failed to load session parent target data",
+				err: err,
+			}
+		}
+		if err := parentAction.SetValues(record.GetParentsTitle()[data.RecordTypeAction]); err != nil {
+			return recordConfigPage{}, internalErrorMsg{
+				msg: "failed to load session parent action data",
+				err: err,
+			}
+		}
+		hiddens["parent_target_uuid"] = record.GetParentsUUID()[data.RecordTypeTarget]
+		hiddens["parent_action_uuid"] = record.GetParentsUUID()[data.RecordTypeAction]
+
+		if hiddens["parent_action_uuid"] != "" {
+			focused = 2
+		}
+	}
+
+	focusables := []Focusable{parentTarget, parentAction, duration, endsAt, note}
+	focusables[focused].Focus()
+
+	return recordConfigPage{
+		cfg:          cfg,
+		action:       cmdLog,
+		record:       record,
+		recordType:   data.RecordTypeSession,
+		title:        "Log time",
+		fields:       focusables,
+		focused:      focused,
+		focusedCache: focused,
+		hiddenFields: hiddens,
+		selectorFields: map[data.RecordType]int{
+			data.RecordTypeTarget: 0,
+			data.RecordTypeAction: 1,
+		},
+		dateFields: map[int]bool{3: true},
+		width:      size.Width,
+		height:     size.Height,
+		prev:       prev,
+		hooks: recordConfigHooks{
+			create: logSession,
+		},
+	}, nil
+}
+
+// newSessionSplitPage creates the form splitting the session in two at the
+// split time, the second half can be moved to another action.
+func newSessionSplitPage(
+	cfg config,
+	size style.ViewSize,
+	session data.Session,
+	prev tea.Model,
+) (recordConfigPage, error) {
+	start, end := sessionInterval(session.StartsAt, session.EndsAt)
+
+	splitAt := timeInput(formWidth, true)
+	splitAt.SetValues(datetime.Display().DateTime(start.Add(end.Sub(start) / 2)))
+
+	parentTarget := components.NewText("", showSelectorMsg{selection: data.RecordTypeTarget})
+	parentTarget.ValidateFunc = validator.ValidateRequired("target is required")
+	if err := parentTarget.SetValues(session.TargetTitle); err != nil {
+		return recordConfigPage{}, internalErrorMsg{
+			msg: "failed to load session parent target data",
+			err: err,
+		}
+	}
+
+	parentAction := components.NewText("", showSelectorMsg{selection: data.RecordTypeAction})
+	parentAction.ValidateFunc = validator.ValidateRequired("action is required")
+	if err := parentAction.SetValues(session.ActionTitle); err != nil {
+		return recordConfigPage{}, internalErrorMsg{
+			msg: "failed to load session parent action data",
+			err: err,
+		}
+	}
+
+	return recordConfigPage{
+		cfg:        cfg,
+		action:     cmdSplit,
+		uuid:       session.UUID,
+		record:     session,
+		recordType: data.RecordTypeSession,
+		title:      "Split session",
+		fields:     []Focusable{splitAt, parentTarget, parentAction},
+		hiddenFields: map[string]string{
+			"parent_target_uuid": session.TargetUUID,
+			"parent_action_uuid": session.ActionUUID,
+		},
+		selectorFields: map[data.RecordType]int{
+			data.RecordTypeTarget: 1,
+			data.RecordTypeAction: 2,
+		},
+		dateFields: map[int]bool{0: true},
+		width:      size.Width,
+		height:     size.Height,
+		prev:       prev,
+		hooks: recordConfigHooks{
+			create: splitSession,
+		},
+	}, nil
+}
+
 func (p recordConfigPage) Init() tea.Cmd {
 	return nil
 }
@@ -326,7 +478,40 @@ func (p recordConfigPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if p.selector != nil {
 			break
 		}
+		if p.datePicker != nil {
+			var cmd tea.Cmd
+			p.datePicker, cmd = p.datePicker.Update(msg)
+			return p, cmd
+		}
+		if p.editor != nil {
+			var cmd tea.Cmd
+			p.editor, cmd = p.editor.Update(msg)
+			return p, cmd
+		}
+		if p.templates != nil {
+			if msg.String() == "esc" {
+				p.templates = nil
+				return p, nil
+			}
+			var cmd tea.Cmd
+			p.templates, cmd = p.templates.Update(msg)
+			return p, cmd
+		}
+		if p.overlap != nil {
+			if msg.String() == "esc" {
+				p.overlap = nil
+				return p, nil
+			}
+			var cmd tea.Cmd
+			p.overlap, cmd = p.overlap.Update(msg)
+			return p, cmd
+		}
 		switch msg.String() {
+		case "ctrl+o":
+			if withTime, ok := p.dateFields[p.focused]; ok {
+				p.datePicker = p.newDatePicker(p.fields[p.focused], withTime)
+				return p, nil
+			}
 		case "tab", "enter":
 			// Cycle through focusable fields
 			p.fields[p.focused].Validate()
@@ -344,7 +529,7 @@ func (p recordConfigPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return p, switchToPreviousCmd(p.prev)
 		case "ctrl+s":
 			switch p.action {
-			case cmdCreate:
+			case cmdCreate, cmdLog, cmdSplit:
 				return p, p.create()
 			case cmdUpdate:
 				p.cfg.logger.Info("updating record", slog.String("uuid", p.uuid))
@@ -390,6 +575,64 @@ func (p recordConfigPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				delete(p.hiddenFields, "parent_action_uuid")
 			}
 		}
+	case components.DatePickerSelectedMsg:
+		p.datePicker = nil
+		value := msg.Value
+		if t, err := datetime.Parse(value, datetime.Now()); err == nil {
+			if p.dateFields[p.focused] {
+				value = datetime.Display().DateTime(t)
+			} else {
+				value = datetime.Display().DueDate(t)
+			}
+		}
+		p.fields[p.focused].SetValues(value)
+		p.fields[p.focused].Validate()
+		return p, nil
+	case components.DatePickerCanceledMsg:
+		p.datePicker = nil
+		return p, nil
+	case model.ShowMarkdownEditorMsg:
+		width, height := formWidth-6, 14
+		if p.recordType == data.RecordTypeSession && p.action != cmdUpdate {
+			width, height = 54, 10
+		}
+		p.editor = model.NewMarkdownEditor(msg.Title, msg.Value, width, height, msg.CharLimit)
+		return p, p.editor.Init()
+	case model.MarkdownEditorSavedMsg:
+		p.editor = nil
+		if err := p.fields[p.focused].SetValues(msg.Value); err != nil {
+			p.err = err
+			return p, nil
+		}
+		p.fields[p.focused].Validate()
+		return p, nil
+	case model.MarkdownEditorCanceledMsg:
+		p.editor = nil
+		return p, nil
+	case model.ShowNoteTemplatesMsg:
+		return p.offerNoteTemplates(msg.Inline)
+	case noteTemplateSelectedMsg:
+		return p.applyNoteTemplate(msg)
+	case sessionOverlapMsg:
+		if len(msg.overlaps) == 0 {
+			return p, p.saveSession(msg.d)
+		}
+		trims, trimmable := overlapTrims(msg.d, msg.overlaps)
+		p.overlap = sessionOverlapAlert(msg.overlaps, trimmable)
+		p.overlapData = msg.d
+		p.overlapTrims = trims
+		return p, nil
+	case sessionOverlapTrimMsg:
+		p.overlap = nil
+		return p, trimAndSaveSession(
+			p.cfg.apiEndpoint, p.overlapTrims, p.saveSession(p.overlapData), p.cfg.authClient,
+		)
+	case sessionOverlapSaveMsg:
+		p.overlap = nil
+		return p, p.saveSession(p.overlapData)
+	case sessionOverlapCancelMsg:
+		p.overlap = nil
+		return p, nil
 	case selectorActionSelectedMsg:
 		p.selector = nil
 		p.fields[p.focusedCache].SetValues(msg.title)
@@ -433,6 +676,13 @@ func (p recordConfigPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, cmd)
 		}
 	}
+	if p.editor != nil {
+		var cmd tea.Cmd
+		p.editor, cmd = p.editor.Update(msg)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
 
 	return p, tea.Batch(cmds...)
 }
@@ -444,6 +694,10 @@ func (p recordConfigPage) View() string {
 			return p.sessionCreateView()
 		case cmdUpdate:
 			return p.sessionConfigView()
+		case cmdLog:
+			return p.sessionLogView()
+		case cmdSplit:
+			return p.sessionSplitView()
 		default:
 			panic("unknown session action")
 		}
@@ -480,7 +734,7 @@ func (p recordConfigPage) tarActConfigView() string {
 			lipgloss.NewStyle().
 				Width(dueDateInputViewWidth).Margin(1, 0, 0, 3).Render(
 				due.prompt(
-					due.textInputPrompt("Due Date", fmt.Sprintf("<f%d>", due.idx+1)),
+					due.textInputPrompt("Due Date", "<C-o>"),
 					due.textInputPrompt("Due Date", fmt.Sprintf("<f%d>", due.idx+1)),
 				),
 			),
@@ -488,7 +742,7 @@ func (p recordConfigPage) tarActConfigView() string {
 
 		lipgloss.NewStyle().Width(formWidth).Margin(1, 5, 0).Render(
 			description.prompt(
-				description.textInputPrompt("Description", fmt.Sprintf("<f%d>", description.idx+1)),
+				description.textInputPrompt("Description", "(Press 'e' to edit, 'i' inline)"),
 				description.textInputPrompt("Description", fmt.Sprintf("<f%d>", description.idx+1)),
 			),
 		),
@@ -503,7 +757,7 @@ func (p recordConfigPage) tarActConfigView() string {
 
 		lipgloss.NewStyle().Width(formWidth).Margin(1, 5, 0).Render(
 			note.prompt(
-				note.simpleTitlePrompt("Note", "(Press 'e' to edit)", true),
+				note.simpleTitlePrompt("Note", "(Press 'e' to edit, 'i' inline)", true),
 				note.simpleTitlePrompt("Note", fmt.Sprintf("<f%d>", note.idx+1), true),
 			),
 		),
@@ -555,11 +809,7 @@ func (p recordConfigPage) tarActConfigView() string {
 		helperView,
 	)
 
-	if p.selector != nil {
-		overlayX := lipgloss.Width(container)/2 - lipgloss.Width(p.selector.View())/2
-		overlayY := lipgloss.Height(container)/2 - lipgloss.Height(p.selector.View())/2
-		container = strview.PlaceOverlay(overlayX, overlayY, p.selector.View(), container)
-	}
+	container = p.placeOverlays(container)
 
 	return style.ContainerStyle(p.width, container, 5).Render(container)
 }
@@ -577,6 +827,13 @@ func (p recordConfigPage) sessionCreateView() string {
 	target := field{idx: 0, obj: p.fields[0]}
 	action := field{idx: 1, obj: p.fields[1]}
 	note := field{idx: 2, obj: p.fields[2]}
+	pomodoro := field{idx: 3, obj: p.fields[3]}
+
+	pomodoroHelper := fmt.Sprintf(
+		"(%s work / %s break)",
+		shortDuration(p.cfg.pomodoro.work),
+		shortDuration(p.cfg.pomodoro.rest),
+	)
 
 	helper := lipgloss.NewStyle().
 		Width(60).
@@ -626,14 +883,167 @@ func (p recordConfigPage) sessionCreateView() string {
 			),
 		),
 		note.prompt(
-			note.simpleTitlePrompt("Note", "(Press 'e' to edit)", true, fieldStyle),
+			note.simpleTitlePrompt("Note", "(Press 'e' to edit, 'i' inline)", true, fieldStyle),
 			note.simpleTitlePrompt("Note", fmt.Sprintf("<f%d>", note.idx+1), true, fieldStyle),
 		),
+		fieldStyle.Render(
+			fmt.Sprintf(
+				"%s\n%s",
+				pomodoro.prompt(
+					pomodoro.simpleTitlePrompt("Pomodoro", pomodoroHelper, false),
+					pomodoro.simpleTitlePrompt(
+						"Pomodoro", fmt.Sprintf("<f%d> %s", pomodoro.idx+1, pomodoroHelper), false,
+					),
+				),
+				style.FormFieldStyle.Content.Render(pomodoro.obj.View()),
+			),
+		),
 		msgView,
 		helper,
 	)
 
-	return style.BorderStyle["highlighted"].Render(form)
+	container := style.BorderStyle["highlighted"].Render(form)
+	container = p.placeOverlays(container)
+
+	return container
+}
+
+func (p recordConfigPage) sessionLogView() string {
+	title := lipgloss.NewStyle().
+		Width(60).
+		Margin(0, 2).
+		AlignHorizontal(lipgloss.Center).
+		Foreground(colors.Secondary).
+		Bold(true).
+		Render(p.title)
+
+	fieldStyle := lipgloss.NewStyle().Width(60).Margin(1, 3, 0)
+	target := field{idx: 0, obj: p.fields[0]}
+	action := field{idx: 1, obj: p.fields[1]}
+	duration := field{idx: 2, obj: p.fields[2]}
+	endsAt := field{idx: 3, obj: p.fields[3]}
+	note := field{idx: 4, obj: p.fields[4]}
+
+	var interval string
+	if startsAt, endsAt, err := p.logInterval(); err == nil {
+		f := datetime.Display()
+		interval = style.Document.NormalDim.Render(
+			"Logs " + f.DateTime(startsAt) + " → " + f.DateTime(endsAt),
+		)
+	}
+
+	msgView := style.ErrorView(style.ViewSize{Width: 66, Height: 1}, p.err, nil)
+
+	form := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		target.prompt(
+			target.selectionPrompt(
+				string(data.RecordTypeTarget), "(Press 'e' to select)", true, fieldStyle,
+			),
+			target.selectionPrompt(
+				string(data.RecordTypeTarget), fmt.Sprintf("<f%d>", target.idx+1), true, fieldStyle,
+			),
+		),
+		action.prompt(
+			action.selectionPrompt(
+				string(data.RecordTypeAction), "(Press 'e' to select)", true, fieldStyle,
+			),
+			action.selectionPrompt(
+				string(data.RecordTypeAction), fmt.Sprintf("<f%d>", action.idx+1), true, fieldStyle,
+			),
+		),
+		fieldStyle.Render(
+			duration.prompt(
+				duration.textInputPrompt("Duration", "(e.g. 1h30m, 90m)"),
+				duration.textInputPrompt("Duration", fmt.Sprintf("<f%d>", duration.idx+1)),
+			),
+		),
+		fieldStyle.Render(
+			endsAt.prompt(
+				endsAt.textInputPrompt("Ends At", "<C-o> (empty for now)"),
+				endsAt.textInputPrompt("Ends At", fmt.Sprintf("<f%d>", endsAt.idx+1)),
+			),
+		),
+		note.prompt(
+			note.simpleTitlePrompt("Note", "(Press 'e' to edit, 'i' inline)", true, fieldStyle),
+			note.simpleTitlePrompt("Note", fmt.Sprintf("<f%d>", note.idx+1), true, fieldStyle),
+		),
+		lipgloss.NewStyle().Width(60).Margin(1, 3, 0).AlignHorizontal(lipgloss.Center).Render(interval),
+		msgView,
+		style.HelperView([]style.HelperContent{
+			{Key: "Esc", Action: "back"},
+			{Key: "Tab/Shift+Tab", Action: "navigate"},
+			{Key: "<C-s>", Action: "log"},
+		}, 66),
+	)
+
+	container := style.BorderStyle["highlighted"].Render(form)
+	container = p.placeOverlays(container)
+
+	return container
+}
+
+func (p recordConfigPage) sessionSplitView() string {
+	title := lipgloss.NewStyle().
+		Width(60).
+		Margin(0, 2).
+		AlignHorizontal(lipgloss.Center).
+		Foreground(colors.Secondary).
+		Bold(true).
+		Render(p.title)
+
+	fieldStyle := lipgloss.NewStyle().Width(60).Margin(1, 3, 0)
+	splitAt := field{idx: 0, obj: p.fields[0]}
+	target := field{idx: 1, obj: p.fields[1]}
+	action := field{idx: 2, obj: p.fields[2]}
+
+	session := p.record.(data.Session)
+	summary := style.Document.NormalDim.Render(
+		"Splitting " + session.ActionTitle + " " + session.GetTitle() + "\n" +
+			"The second half goes to the action below",
+	)
+
+	msgView := style.ErrorView(style.ViewSize{Width: 66, Height: 1}, p.err, nil)
+
+	form := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		lipgloss.NewStyle().Width(60).Margin(1, 3, 0).AlignHorizontal(lipgloss.Center).Render(summary),
+		fieldStyle.Render(
+			splitAt.prompt(
+				splitAt.textInputPrompt("Split At", "<C-o>"),
+				splitAt.textInputPrompt("Split At", fmt.Sprintf("<f%d>", splitAt.idx+1)),
+			),
+		),
+		target.prompt(
+			target.selectionPrompt(
+				string(data.RecordTypeTarget), "(Press 'e' to select)", true, fieldStyle,
+			),
+			target.selectionPrompt(
+				string(data.RecordTypeTarget), fmt.Sprintf("<f%d>", target.idx+1), true, fieldStyle,
+			),
+		),
+		action.prompt(
+			action.selectionPrompt(
+				string(data.RecordTypeAction), "(Press 'e' to select)", true, fieldStyle,
+			),
+			action.selectionPrompt(
+				string(data.RecordTypeAction), fmt.Sprintf("<f%d>", action.idx+1), true, fieldStyle,
+			),
+		),
+		msgView,
+		style.HelperView([]style.HelperContent{
+			{Key: "Esc", Action: "back"},
+			{Key: "Tab/Shift+Tab", Action: "navigate"},
+			{Key: "<C-s>", Action: "split"},
+		}, 66),
+	)
+
+	container := style.BorderStyle["highlighted"].Render(form)
+	container = p.placeOverlays(container)
+
+	return container
 }
 
 func (p recordConfigPage) sessionConfigView() string {
@@ -667,20 +1077,20 @@ func (p recordConfigPage) sessionConfigView() string {
 			lipgloss.Top,
 			lipgloss.NewStyle().Width(30).Margin(1, 5, 0, 5).Render(
 				startsAt.prompt(
-					startsAt.textInputPrompt("Starts At", fmt.Sprintf("<f%d>", startsAt.idx+1)),
+					startsAt.textInputPrompt("Starts At", "<C-o>"),
 					startsAt.textInputPrompt("Starts At", fmt.Sprintf("<f%d>", startsAt.idx+1)),
 				),
 			),
 			lipgloss.NewStyle().Width(30).Margin(1, 5, 0, 5).Render(
 				endsAt.prompt(
-					endsAt.textInputPrompt("Ends At", fmt.Sprintf("<f%d>", endsAt.idx+1)),
+					endsAt.textInputPrompt("Ends At", "<C-o>"),
 					endsAt.textInputPrompt("Ends At", fmt.Sprintf("<f%d>", endsAt.idx+1)),
 				),
 			),
 		),
 		lipgloss.NewStyle().Width(formWidth).Margin(1, 5, 0).Render(
 			note.prompt(
-				note.simpleTitlePrompt("Note", "(Press 'e' to edit)", true),
+				note.simpleTitlePrompt("Note", "(Press 'e' to edit, 'i' inline)", true),
 				note.simpleTitlePrompt("Note", fmt.Sprintf("<f%d>", note.idx+1), true),
 			),
 		),
@@ -706,15 +1116,26 @@ func (p recordConfigPage) sessionConfigView() string {
 		helperView,
 	)
 
-	if p.selector != nil {
-		overlayX := lipgloss.Width(container)/2 - lipgloss.Width(p.selector.View())/2
-		overlayY := lipgloss.Height(container)/2 - lipgloss.Height(p.selector.View())/2
-		container = strview.PlaceOverlay(overlayX, overlayY, p.selector.View(), container)
-	}
+	container = p.placeOverlays(container)
 
 	return style.ContainerStyle(p.width, container, 5).Render(container)
 }
 
+// placeOverlays places the open popups of the page at the center of the
+// container.
+func (p recordConfigPage) placeOverlays(container string) string {
+	for _, popup := range []tea.Model{p.selector, p.datePicker, p.editor, p.templates, p.overlap} {
+		if popup == nil {
+			continue
+		}
+		view := popup.View()
+		overlayX := lipgloss.Width(container)/2 - lipgloss.Width(view)/2
+		overlayY := lipgloss.Height(container)/2 - lipgloss.Height(view)/2
+		container = strview.PlaceOverlay(overlayX, overlayY, view, container)
+	}
+	return container
+}
+
 func (p recordConfigPage) validationError() error {
 	focusables := []Focusable{}
 	for _, f := range p.fields {
@@ -731,6 +1152,19 @@ func (p recordConfigPage) create() tea.Cmd {
 	case data.RecordTypeTarget, data.RecordTypeAction:
 		d, cmd = p.tarActCreate()
 	case data.RecordTypeSession:
+		if p.action == cmdLog {
+			d, cmd = p.sessionLog()
+			if cmd == nil {
+				cmd = checkSessionOverlap(
+					p.cfg.apiEndpoint, d, p.cfg.maxSessionDuration, p.cfg.authClient,
+				)
+			}
+			break
+		}
+		if p.action == cmdSplit {
+			d, cmd = p.sessionSplit()
+			break
+		}
 		d, cmd = p.sessionCreate()
 	default:
 		panic("create record with unknown type: " + string(p.recordType))
@@ -740,6 +1174,12 @@ func (p recordConfigPage) create() tea.Cmd {
 		return cmd
 	}
 
+	if p.pomodoroEnabled() {
+		return createPomodoroSession(
+			p.cfg.apiEndpoint, p.fields[1].Value(), d, p, p.prevPage(), p.cfg.authClient,
+		)
+	}
+
 	return p.hooks.create(p.cfg.apiEndpoint, d, p, p.prevPage(), p.cfg.authClient)
 }
 
@@ -754,6 +1194,11 @@ func (p recordConfigPage) tarActCreate() (recordRequestData, tea.Cmd) {
 		return recordRequestData{}, validationErrorCmd(err)
 	}
 
+	due, err := resolveDueDate(due)
+	if err != nil {
+		return recordRequestData{}, validationErrorCmd(err)
+	}
+
 	d := recordRequestData{
 		title:       title,
 		description: description,
@@ -794,6 +1239,111 @@ func (p recordConfigPage) sessionCreate() (recordRequestData, tea.Cmd) {
 	return d, nil
 }
 
+func (p recordConfigPage) sessionLog() (recordRequestData, tea.Cmd) {
+	targetUUID := p.hiddenFields["parent_target_uuid"]
+	actionUUID := p.hiddenFields["parent_action_uuid"]
+	note := p.fields[4].Value()
+
+	if err := p.validationError(); err != nil {
+		return recordRequestData{}, validationErrorCmd(err)
+	}
+
+	if targetUUID == "" {
+		return recordRequestData{}, validationErrorCmd(errors.New("target is required"))
+	}
+	if actionUUID == "" {
+		return recordRequestData{}, validationErrorCmd(errors.New("action is required"))
+	}
+
+	startsAt, endsAt, err := p.logInterval()
+	if err != nil {
+		return recordRequestData{}, validationErrorCmd(err)
+	}
+	if err := validateSessionInterval(
+		startsAt, endsAt, datetime.Now(), p.cfg.maxSessionDuration,
+	); err != nil {
+		return recordRequestData{}, validationErrorCmd(err)
+	}
+
+	d := recordRequestData{
+		targetUUID: targetUUID,
+		actionUUID: actionUUID,
+		startsAt:   startsAt,
+		endsAt:     sql.NullTime{Time: endsAt, Valid: true},
+		note:       nullNote{valid: true, note: note},
+	}
+
+	return d, nil
+}
+
+func (p recordConfigPage) sessionSplit() (recordRequestData, tea.Cmd) {
+	actionUUID := p.hiddenFields["parent_action_uuid"]
+
+	if err := p.validationError(); err != nil {
+		return recordRequestData{}, validationErrorCmd(err)
+	}
+	if actionUUID == "" {
+		return recordRequestData{}, validationErrorCmd(errors.New("action is required"))
+	}
+
+	now := datetime.Now()
+	splitAt, err := datetime.Parse(p.fields[0].Value(), now)
+	if err != nil {
+		return recordRequestData{}, validationErrorCmd(errors.New("invalid split time value"))
+	}
+	if err := validateSplitTime(p.record.(data.Session), splitAt, now); err != nil {
+		return recordRequestData{}, validationErrorCmd(err)
+	}
+
+	d := recordRequestData{
+		uuid:       p.uuid,
+		actionUUID: actionUUID,
+		startsAt:   splitAt,
+	}
+
+	return d, nil
+}
+
+// logInterval computes the interval of the logged session from its duration
+// and end time, an empty end time means now.
+func (p recordConfigPage) logInterval() (time.Time, time.Time, error) {
+	duration, err := datetime.ParseDuration(p.fields[2].Value())
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("invalid duration value")
+	}
+
+	now := datetime.Now()
+	endsAt := now.Truncate(time.Second)
+	if input := p.fields[3].Value(); input != "" {
+		endsAt, err = datetime.Parse(input, now)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("invalid ends at value")
+		}
+	}
+
+	return endsAt.Add(-duration), endsAt, nil
+}
+
+// saveSession saves the session once checked for overlaps, logged sessions
+// are created while others are updated.
+func (p recordConfigPage) saveSession(d recordRequestData) tea.Cmd {
+	if p.action == cmdLog {
+		return p.hooks.create(p.cfg.apiEndpoint, d, p, p.prevPage(), p.cfg.authClient)
+	}
+
+	return p.hooks.update(p.cfg.apiEndpoint, "", d, p, p.prevPage(), p.cfg.authClient)
+}
+
+// pomodoroEnabled reports whether the new session should be tracked by a
+// pomodoro work/break cycle.
+func (p recordConfigPage) pomodoroEnabled() bool {
+	if p.recordType != data.RecordTypeSession || p.action != cmdCreate || len(p.fields) < 4 {
+		return false
+	}
+
+	return p.fields[3].Value() == "on"
+}
+
 func (p recordConfigPage) update() tea.Cmd {
 	var d recordRequestData
 	var cmd tea.Cmd
@@ -802,6 +1352,11 @@ func (p recordConfigPage) update() tea.Cmd {
 		d, cmd = p.tarActUpdate()
 	case data.RecordTypeSession:
 		d, cmd = p.sessionUpdate()
+		if cmd == nil {
+			cmd = checkSessionOverlap(
+				p.cfg.apiEndpoint, d, p.cfg.maxSessionDuration, p.cfg.authClient,
+			)
+		}
 	default:
 		panic("update record with unknown type: " + string(p.recordType))
 	}
@@ -824,6 +1379,11 @@ func (p recordConfigPage) tarActUpdate() (recordRequestData, tea.Cmd) {
 		return recordRequestData{}, validationErrorCmd(err)
 	}
 
+	due, err := resolveDueDate(due)
+	if err != nil {
+		return recordRequestData{}, validationErrorCmd(err)
+	}
+
 	d := recordRequestData{
 		uuid:        p.uuid,
 		title:       title,
@@ -831,6 +1391,7 @@ func (p recordConfigPage) tarActUpdate() (recordRequestData, tea.Cmd) {
 		status:      status,
 		note:        nullNote{valid: true, note: note},
 		dueDate:     due,
+		before:      p.record,
 	}
 	if p.recordType == data.RecordTypeAction {
 		d.targetUUID = p.hiddenFields["parent_target_uuid"]
@@ -857,8 +1418,8 @@ func (p recordConfigPage) sessionUpdate() (recordRequestData, tea.Cmd) {
 		return recordRequestData{}, validationErrorCmd(errors.New("action is required"))
 	}
 
-	layout := "2006-01-02 15:04:05"
-	startsAtTime, err := time.ParseInLocation(layout, startsAt, time.Local)
+	now := datetime.Now()
+	startsAtTime, err := datetime.Parse(startsAt, now)
 	if err != nil {
 		return recordRequestData{}, validationErrorCmd(errors.New("invalid starts at value"))
 	}
@@ -872,19 +1433,55 @@ func (p recordConfigPage) sessionUpdate() (recordRequestData, tea.Cmd) {
 			valid: true,
 			note:  note,
 		},
+		before: p.record,
 	}
 
 	if endsAt != "" {
-		endsAtTime, err := time.ParseInLocation(layout, endsAt, time.Local)
+		endsAtTime, err := datetime.Parse(endsAt, now)
 		if err != nil {
 			return recordRequestData{}, validationErrorCmd(errors.New("invalid ends at value"))
 		}
+		if err := validateSessionInterval(
+			startsAtTime, endsAtTime, now, p.cfg.maxSessionDuration,
+		); err != nil {
+			return recordRequestData{}, validationErrorCmd(err)
+		}
 		d.endsAt = sql.NullTime{Time: endsAtTime, Valid: true}
 	}
 
 	return d, nil
 }
 
+// newDatePicker creates the date picker for the date field, starting from the
+// date currently in the field and following the field constraints.
+func (p recordConfigPage) newDatePicker(f Focusable, withTime bool) tea.Model {
+	value, err := datetime.Parse(f.Value(), datetime.Now())
+	if err != nil {
+		value = datetime.Now()
+	}
+
+	picker := components.NewDatePicker(value, withTime)
+	if input, ok := f.(*model.TextInputWrapper); ok {
+		picker.ValidateFunc = input.ValidateFunc()
+	}
+
+	return picker
+}
+
+// resolveDueDate resolves the due date input to the YYYY-mm-dd format expected
+// by the API.
+func resolveDueDate(input string) (string, error) {
+	if input == "" {
+		return "", nil
+	}
+
+	due, err := datetime.ParseDate(input, datetime.Now())
+	if err != nil {
+		return "", errors.New("invalid due date value")
+	}
+	return due.Format(datetime.APIDateLayout), nil
+}
+
 func (p recordConfigPage) prevPage() tea.Model {
 	if v, ok := p.prev.(listPage); ok {
 		parentType := v.recordType.GetParentType()
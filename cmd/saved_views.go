@@ -0,0 +1,224 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/liuminhaw/yatijapp-tui/internal/authclient"
+	"github.com/liuminhaw/yatijapp-tui/internal/data"
+	"github.com/liuminhaw/yatijapp-tui/internal/style"
+	"github.com/liuminhaw/yatijapp-tui/internal/validator"
+)
+
+const (
+	// savedViewsPerPage and savedViewsMax fit the saved views in the two pages
+	// of the menu.
+	savedViewsPerPage = 8
+	savedViewsMax     = 2 * savedViewsPerPage
+	savedViewNameMax  = 22
+)
+
+type viewsUpdatedMsg struct {
+	view        string // name of the saved view, empty for deletions
+	msg         string
+	preferences data.Preferences
+	err         error
+}
+
+// updateViewsCmd syncs the preferences after the saved views changed,
+// reporting the outcome with msg. The preferences in use are replaced only
+// once the update succeeds.
+func updateViewsCmd(
+	serverURL string,
+	preferences data.Preferences,
+	view, msg string,
+	client *authclient.AuthClient,
+) tea.Cmd {
+	return func() tea.Msg {
+		request := data.NewPreferencesRequestBody(preferences)
+		if err := request.Update(serverURL, client); err != nil {
+			return viewsUpdatedMsg{err: fmt.Errorf("failed to update preferences: %w", err)}
+		}
+		return viewsUpdatedMsg{view: view, msg: msg, preferences: preferences}
+	}
+}
+
+// savedViewsAuthView lists the saved views in the menu.
+func savedViewsAuthView(prev *authView, views []data.View) *authView {
+	var pages [][]string
+	for chunk := range slices.Chunk(views, savedViewsPerPage) {
+		names := make([]string, 0, len(chunk))
+		for _, v := range chunk {
+			names = append(names, v.Name)
+		}
+		pages = append(pages, names)
+	}
+
+	return &authView{
+		name:     "views",
+		view:     menuView(pages),
+		greeting: "Views",
+		prev:     prev,
+	}
+}
+
+// savedViewSummary describes the record type, search and filter of the view.
+func savedViewSummary(v data.View) []string {
+	lines := []string{
+		"Type:   " + string(v.RecordType) + "s",
+		"Status: " + strings.Join(v.Filter.Status, ", "),
+		"Sort:   " + v.Filter.SortOption() + " (" + v.Filter.SortOrder + ")",
+	}
+	switch v.Filter.Due {
+	case data.DueAny:
+	case data.DueCustom:
+		lines = append(lines, "Due:    "+v.Filter.DueFrom+" → "+v.Filter.DueTo)
+	default:
+		lines = append(lines, "Due:    "+v.Filter.Due)
+	}
+	if v.Search != "" {
+		lines = append(lines, "Search: "+v.Search)
+	}
+	return lines
+}
+
+// newSavedViewListPage lists the records of the view, across all parents.
+func newSavedViewListPage(
+	cfg config,
+	termSize style.ViewSize,
+	view data.View,
+	prev tea.Model,
+) listPage {
+	var page listPage
+	switch view.RecordType {
+	case data.RecordTypeTarget:
+		page = newTargetListPage(cfg, termSize, data.RecordParents{}, prev)
+	case data.RecordTypeAction:
+		page = newActionListPage(cfg, termSize, data.RecordParents{}, prev)
+	case data.RecordTypeSession:
+		page = newSessionListPage(cfg, termSize, data.RecordParents{}, prev)
+	default:
+		panic("unsupported record type in saved view")
+	}
+
+	page.selectionFilterQuery(data.RecordFilter{RecordType: view.RecordType, Filter: view.Filter})
+	page.selectionSearchQuery(view.Search)
+	page.view = view.Name
+
+	return page
+}
+
+// saveViewPage names the current list view, saving it to the preferences.
+type saveViewPage struct {
+	cfg config
+
+	view  data.View
+	field Focusable
+	err   error
+}
+
+func newSaveViewPage(cfg config, view data.View) saveViewPage {
+	field := generalInput(inputFieldConfig{
+		width:       formWidth - 2,
+		focus:       true,
+		placeholder: "View name",
+		lenMax:      savedViewNameMax,
+		validators: []func(string) error{
+			validator.ValidateRequired("name is required"),
+			validator.ValidateMaxLength(savedViewNameMax),
+		},
+	})
+	if view.Name != "" {
+		field.SetValues(view.Name)
+	}
+
+	return saveViewPage{cfg: cfg, view: view, field: field}
+}
+
+func (s saveViewPage) Init() tea.Cmd {
+	return nil
+}
+
+func (s saveViewPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "ctrl+c":
+			return s, tea.Quit
+		case "esc", "ctrl+[":
+			return s, cancelPopupCmd
+		case "enter":
+			return s.save()
+		}
+	}
+
+	retModel, retCmd := s.field.Update(msg)
+	s.field = retModel.(Focusable)
+	s.err = nil
+
+	return s, retCmd
+}
+
+// save adds the view to the preferences, replacing the view of the same name.
+func (s saveViewPage) save() (tea.Model, tea.Cmd) {
+	s.field.Validate()
+	if s.field.Error() != "" {
+		s.err = errors.New(s.field.Error())
+		return s, nil
+	}
+
+	s.view.Name = strings.TrimSpace(s.field.Value())
+	preferences := s.cfg.preferences.Clone()
+	if _, ok := preferences.View(s.view.Name); !ok && len(preferences.Views) >= savedViewsMax {
+		s.err = fmt.Errorf("at most %d views can be saved", savedViewsMax)
+		return s, nil
+	}
+	preferences.SaveView(s.view)
+
+	return s, tea.Batch(
+		cancelPopupCmd,
+		updateViewsCmd(
+			s.cfg.apiEndpoint,
+			preferences,
+			s.view.Name,
+			fmt.Sprintf("View %q saved", s.view.Name),
+			s.cfg.authClient,
+		),
+	)
+}
+
+func (s saveViewPage) View() string {
+	title := style.InputStyle.Selected.Width(formWidth).
+		AlignHorizontal(lipgloss.Center).
+		Margin(0, 0, 1).
+		Render("Save View")
+
+	summary := style.Document.NormalDim.Width(formWidth-2).Padding(0, 1).Render(
+		strings.Join(savedViewSummary(s.view), "\n"),
+	)
+
+	status := ""
+	if s.err != nil {
+		status = style.ErrorStyle.Width(formWidth-2).Padding(0, 1).Render(s.err.Error())
+	}
+
+	helper := style.HelperView([]style.HelperContent{
+		{Key: "Esc", Action: "cancel"},
+		{Key: "Enter", Action: "save"},
+	}, formWidth)
+
+	form := lipgloss.JoinVertical(
+		lipgloss.Center,
+		title,
+		style.FormFieldStyle.Content.Width(formWidth-2).Render(s.field.View()),
+		status,
+		summary,
+		"",
+		helper,
+	)
+
+	return style.BorderStyle["highlighted"].Width(formWidth).Render(form)
+}
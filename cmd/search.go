@@ -1,18 +1,70 @@
 package main
 
 import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/liuminhaw/yatijapp-tui/colors"
 	"github.com/liuminhaw/yatijapp-tui/internal/data"
+	"github.com/liuminhaw/yatijapp-tui/internal/datetime"
+	"github.com/liuminhaw/yatijapp-tui/internal/query"
 	"github.com/liuminhaw/yatijapp-tui/internal/style"
 	"github.com/liuminhaw/yatijapp-tui/internal/validator"
 )
 
+const (
+	searchDebounce   = 250 * time.Millisecond
+	searchResultsMax = 8
+)
+
+type (
+	searchDebounceMsg struct{ seq int }
+	searchResultsMsg  struct {
+		seq     int
+		records []yatijappRecord
+		err     error
+	}
+	// openSearchResultMsg closes the search popup of prev before viewing the
+	// selected record.
+	openSearchResultMsg struct {
+		record yatijappRecord
+		prev   tea.Model
+	}
+)
+
 type searchPage struct {
 	cfg config
 
 	scope data.RecordType
 	field Focusable
+	err   error // parse error of the query
+
+	// Incremental results, refreshed as the query is typed. Only the
+	// response to the latest request, numbered seq, is kept.
+	incremental bool
+	seq         int
+	searching   bool
+	results     []yatijappRecord
+	resultsErr  error
+	cursor      int // selected result, -1 while typing the query
+
+	// Search history of the profile, most recent first, recalled with the
+	// arrows or reverse searched with ctrl+r
+	history      []string
+	historyIdx   int    // recalled query, -1 for the typed one
+	draft        string // query typed before recalling or completing
+	reverse      bool
+	reverseQuery string
+	reverseIdx   int
+
+	// Suggestions cycled through with tab, nil until tab is pressed
+	suggested  []string
+	suggestIdx int
 
 	width  int
 	height int
@@ -33,23 +85,31 @@ func newSearchPage(
 		placeholder: "Search...",
 		lenMax:      fieldWidth - 1,
 		validators: []func(string) error{
-			validator.ValidateRequired("required"),
 			validator.ValidateMaxLength(fieldWidth - 1),
 		},
+		highlight: highlightQuery,
 	})
 
 	if content != "" {
 		field.SetValues(content)
 	}
 
-	return searchPage{
-		cfg:    cfg,
-		scope:  scope,
-		field:  field,
-		width:  size.Width,
-		height: size.Height,
-		prev:   prev,
+	s := searchPage{
+		cfg:         cfg,
+		scope:       scope,
+		field:       field,
+		incremental: true,
+		cursor:      -1,
+		history:     loadSearchHistory(cfg),
+		historyIdx:  -1,
+		reverseIdx:  -1,
+		width:       size.Width,
+		height:      size.Height,
+		prev:        prev,
 	}
+	_, s.err = s.parse()
+
+	return s
 }
 
 func (s searchPage) Init() tea.Cmd {
@@ -58,24 +118,162 @@ func (s searchPage) Init() tea.Cmd {
 
 func (s searchPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case searchDebounceMsg:
+		if msg.seq != s.seq {
+			return s, nil
+		}
+		return s, s.search()
+	case searchResultsMsg:
+		if msg.seq != s.seq {
+			return s, nil
+		}
+		s.searching = false
+		s.results, s.resultsErr = msg.records, msg.err
+		s.cursor = min(s.cursor, len(s.results)-1)
+		return s, nil
 	case tea.KeyMsg:
+		if s.reverse {
+			return s, s.updateReverse(msg)
+		}
+		if msg.String() != "tab" {
+			s.suggested = nil
+		}
+
 		switch msg.String() {
 		case "ctrl+c":
 			return s, tea.Quit
 		case "esc", "ctrl+[":
 			return s, switchToPreviousCmd(s.prev)
+		case "ctrl+t":
+			s.incremental = !s.incremental
+			return s, s.refresh()
+		case "ctrl+r":
+			s.startReverse()
+			return s, nil
+		case "tab":
+			return s, s.nextSuggestion()
+		case "down", "ctrl+n":
+			if s.cursor == -1 && s.historyIdx >= 0 && msg.String() == "down" {
+				return s, s.recall(false)
+			}
+			if s.cursor < len(s.results)-1 {
+				s.cursor++
+			}
+			return s, nil
+		case "up", "ctrl+p":
+			if s.cursor == -1 && msg.String() == "up" {
+				return s, s.recall(true)
+			}
+			if s.cursor >= 0 {
+				s.cursor--
+			}
+			return s, nil
 		case "enter":
+			save := saveSearchQuery(s.cfg, s.field.Value())
+			if s.cursor >= 0 {
+				return s, tea.Batch(save, func() tea.Msg {
+					return openSearchResultMsg{record: s.results[s.cursor], prev: s.closedPrev()}
+				})
+			}
+			if s.err != nil {
+				return s, nil
+			}
 			if s.scope == data.RecordTypeAll {
-				return s, switchToSearchListCmd(s.field.Value())
+				if strings.TrimSpace(s.field.Value()) == "" {
+					return s, nil
+				}
+				return s, tea.Batch(save, switchToSearchListCmd(s.field.Value()))
 			}
-			return s, switchToPreviousCmd(s.prevPage())
+			return s, tea.Batch(save, switchToPreviousCmd(s.prevPage()))
 		}
 	}
 
+	value := s.field.Value()
 	retModel, retCmd := s.field.Update(msg)
 	s.field = retModel.(Focusable)
+	if s.field.Value() == value {
+		return s, retCmd
+	}
+
+	_, s.err = s.parse()
+	s.cursor = -1
+	s.historyIdx = -1
+	return s, tea.Batch(retCmd, s.refresh())
+}
+
+// refresh schedules the search of the current query, once typing pauses.
+func (s *searchPage) refresh() tea.Cmd {
+	s.seq++
+	if !s.incremental || s.err != nil || strings.TrimSpace(s.field.Value()) == "" {
+		s.searching = false
+		s.results, s.resultsErr = nil, nil
+		s.cursor = -1
+		return nil
+	}
+
+	s.searching = true
+	seq := s.seq
+	return tea.Tick(searchDebounce, func(time.Time) tea.Msg {
+		return searchDebounceMsg{seq: seq}
+	})
+}
+
+// search requests the records matching the query, in the scope of the
+// search and the parent records of the list it was opened from.
+func (s searchPage) search() tea.Cmd {
+	q, err := s.parse()
+	if err != nil {
+		return nil
+	}
+
+	qs := q.QueryStrings()
+	qs["page_size"] = strconv.Itoa(searchResultsMax)
+	info := data.ListRequestInfo{ServerURL: s.cfg.apiEndpoint, QueryStrings: qs}
+	if l, ok := s.prev.(listPage); ok && s.scope == l.recordType {
+		info.SrcUUID = l.src[l.recordType.GetParentType()].UUID
+	}
+
+	var load tea.Cmd
+	switch s.scope {
+	case data.RecordTypeAll:
+		load = loadAllRecords(info, "", "search", s.cfg.authClient)
+	case data.RecordTypeTarget:
+		load = loadAllTargets(info, "", "search", s.cfg.authClient)
+	case data.RecordTypeAction:
+		load = loadAllActions(info, "", "search", s.cfg.authClient)
+	case data.RecordTypeSession:
+		load = loadAllSessions(info, "", "search", s.cfg.authClient)
+	}
 
-	return s, retCmd
+	seq := s.seq
+	match := queryMatch(q)
+	return func() tea.Msg {
+		switch msg := load().(type) {
+		case allRecordsLoadedMsg:
+			records := slices.DeleteFunc(msg.records, func(r yatijappRecord) bool {
+				return !match(r)
+			})
+			return searchResultsMsg{seq: seq, records: records}
+		case error:
+			return searchResultsMsg{seq: seq, err: msg}
+		default:
+			return nil
+		}
+	}
+}
+
+// parse checks the query of the field. Searching all records only knows the
+// status and notes of the records, the other terms are rejected.
+func (s searchPage) parse() (query.Query, error) {
+	q, err := query.Parse(s.field.Value(), datetime.Now())
+	if err != nil {
+		return q, err
+	}
+	if s.scope == data.RecordTypeAll &&
+		(q.DueOp != "" || q.Target != "" || slices.Contains(q.Has, "due")) {
+		return q, errors.New("due and target terms need a record type to search")
+	}
+	return q, nil
 }
 
 func (s searchPage) View() string {
@@ -91,33 +289,93 @@ func (s searchPage) View() string {
 		panic("unknown search scope: " + s.scope)
 	}
 
-	helper := lipgloss.NewStyle().Width(formWidth).AlignHorizontal(lipgloss.Center).Render(
-		lipgloss.StyleRanges(
-			"Esc back    Enter search",
-			lipgloss.Range{Start: 0, End: 3, Style: style.Document.Primary},
-			lipgloss.Range{Start: 4, End: 8, Style: style.Document.Normal},
-			lipgloss.Range{Start: 12, End: 17, Style: style.Document.Primary},
-			lipgloss.Range{Start: 18, End: 24, Style: style.Document.Normal},
-		),
-	)
+	status := style.HelperStyle.Action.Render("terms: status: due: target: has: sort:")
+	if s.reverse {
+		status = s.reverseView()
+	} else if s.err != nil {
+		status = lipgloss.NewStyle().Foreground(colors.Danger).Render(s.err.Error())
+	}
+	status = lipgloss.NewStyle().Width(formWidth-2).Padding(0, 1).Render(status)
+
+	enterAction := "search"
+	if s.cursor >= 0 {
+		enterAction = "open"
+	}
+	incremental := "incremental off"
+	if s.incremental {
+		incremental = "incremental on"
+	}
+	helper := style.HelperView([]style.HelperContent{
+		{Key: "Esc", Action: "back"},
+		{Key: "Enter", Action: enterAction},
+		{Key: "↑/↓", Action: "history/results"},
+		{Key: "ctrl+r", Action: "reverse search"},
+		{Key: "Tab", Action: "complete"},
+		{Key: "ctrl+t", Action: incremental},
+	}, formWidth)
 
 	title = style.InputStyle.Selected.Width(formWidth).
 		AlignHorizontal(lipgloss.Center).
 		Margin(0, 0, 1).
 		Render(title)
 
-	form := lipgloss.JoinVertical(
-		lipgloss.Center,
+	parts := []string{
 		title,
 		style.FormFieldStyle.Content.
-			Width(formWidth-2).
+			Width(formWidth - 2).
 			Render(search.obj.View()),
-		helper,
-	)
+		status,
+	}
+	for _, part := range []string{s.suggestionsView(formWidth - 2), s.resultsView(formWidth - 2)} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	parts = append(parts, helper)
+	form := lipgloss.JoinVertical(lipgloss.Center, parts...)
 
 	return style.BorderStyle["highlighted"].Width(formWidth).Render(form)
 }
 
+// resultsView lists the incremental results, the selected one highlighted.
+func (s searchPage) resultsView(width int) string {
+	if !s.incremental || strings.TrimSpace(s.field.Value()) == "" || s.err != nil {
+		return ""
+	}
+
+	var content string
+	switch {
+	case s.resultsErr != nil:
+		content = style.ErrorStyle.Render(s.resultsErr.Error())
+	case s.searching && len(s.results) == 0:
+		content = style.Document.NormalDim.Render("Searching...")
+	case len(s.results) == 0:
+		content = style.Document.NormalDim.Render("No matches")
+	default:
+		rows := make([]string, len(s.results))
+		for i, record := range s.results {
+			rows[i] = strings.TrimSuffix(record.ListItemView(false, i == s.cursor, width-2), "\n")
+		}
+		content = lipgloss.JoinVertical(lipgloss.Left, rows...)
+	}
+
+	return lipgloss.NewStyle().Width(width).MarginTop(1).Render(content)
+}
+
+// popupCloser is a page which can show the search as a popup.
+type popupCloser interface {
+	closePopups() tea.Model
+}
+
+// closedPrev returns the page the search was opened from, without the search
+// popup.
+func (s searchPage) closedPrev() tea.Model {
+	if v, ok := s.prev.(popupCloser); ok {
+		return v.closePopups()
+	}
+	return s.prev
+}
+
 func (s searchPage) prevPage() tea.Model {
 	search := s.field.Value()
 
@@ -128,3 +386,61 @@ func (s searchPage) prevPage() tea.Model {
 
 	panic("previous page is not listPage")
 }
+
+// highlightQuery styles the terms of the search query, the invalid ones stand
+// out.
+func highlightQuery(input string) string {
+	q, _ := query.Parse(input, datetime.Now())
+	styles := map[query.TokenKind]lipgloss.Style{
+		query.TokenText:    style.Document.Normal,
+		query.TokenKey:     style.Document.Primary,
+		query.TokenValue:   style.Document.Secondary,
+		query.TokenInvalid: lipgloss.NewStyle().Foreground(colors.Danger).Underline(true),
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, t := range q.Tokens {
+		b.WriteString(input[last:t.Start])
+		b.WriteString(styles[t.Kind].Render(input[t.Start:t.End]))
+		last = t.End
+	}
+	b.WriteString(input[last:])
+
+	return b.String()
+}
+
+// queryMatch returns the client-side match of the search query terms.
+func queryMatch(q query.Query) func(yatijappRecord) bool {
+	return func(r yatijappRecord) bool {
+		due, hasDue := r.GetDueDate()
+		target := r.GetParentsTitle()[data.RecordTypeTarget]
+		if r.GetActualType() == data.RecordTypeTarget {
+			target = r.GetTitle()
+		}
+
+		return q.Match(query.Fields{
+			Status:      r.GetStatus(),
+			TargetTitle: target,
+			Due:         due,
+			HasDue:      hasDue,
+			HasNotes:    r.HasNote(),
+		})
+	}
+}
+
+// matchAll returns the match of the records passing every match, nil when
+// there are none.
+func matchAll(matches []func(yatijappRecord) bool) func(yatijappRecord) bool {
+	if len(matches) == 0 {
+		return nil
+	}
+	return func(r yatijappRecord) bool {
+		for _, match := range matches {
+			if !match(r) {
+				return false
+			}
+		}
+		return true
+	}
+}
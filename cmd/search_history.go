@@ -0,0 +1,226 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/liuminhaw/yatijapp-tui/internal/style"
+)
+
+const searchSuggestionsMax = 5
+
+func loadSearchHistory(cfg config) []string {
+	queries, err := cfg.searchHistory.Queries(profileKey(cfg))
+	if err != nil {
+		cfg.logger.Error(err.Error(), slog.String("action", "load search history"))
+	}
+	return queries
+}
+
+// saveSearchQuery records the query in the search history of the profile.
+func saveSearchQuery(cfg config, query string) tea.Cmd {
+	return func() tea.Msg {
+		if err := cfg.searchHistory.Add(profileKey(cfg), query); err != nil {
+			cfg.logger.Error(err.Error(), slog.String("action", "save search query"))
+		}
+		return nil
+	}
+}
+
+// setQuery replaces the query of the field, with the cursor at its end.
+func (s *searchPage) setQuery(query string) tea.Cmd {
+	s.field.SetValues(query)
+	if f, ok := s.field.(interface{ CursorEnd() }); ok {
+		f.CursorEnd()
+	}
+
+	_, s.err = s.parse()
+	s.cursor = -1
+	return s.refresh()
+}
+
+// recall steps through the search history, older queries first. Stepping
+// past the most recent query restores the typed one.
+func (s *searchPage) recall(older bool) tea.Cmd {
+	switch {
+	case older && s.historyIdx < len(s.history)-1:
+		if s.historyIdx == -1 {
+			s.draft = s.field.Value()
+		}
+		s.historyIdx++
+		return s.setQuery(s.history[s.historyIdx])
+	case !older && s.historyIdx > 0:
+		s.historyIdx--
+		return s.setQuery(s.history[s.historyIdx])
+	case !older && s.historyIdx == 0:
+		s.historyIdx = -1
+		return s.setQuery(s.draft)
+	}
+	return nil
+}
+
+// startReverse starts the reverse search of the history, like ctrl+r in a
+// shell.
+func (s *searchPage) startReverse() {
+	s.reverse = true
+	s.reverseQuery = ""
+	s.reverseIdx = -1
+	s.draft = s.field.Value()
+}
+
+// updateReverse handles the keys while reverse searching the history. The
+// field shows the matched query, enter keeps it and esc restores the typed
+// query.
+func (s *searchPage) updateReverse(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c":
+		return tea.Quit
+	case "enter":
+		s.reverse = false
+		return nil
+	case "esc", "ctrl+[", "ctrl+g":
+		s.reverse = false
+		return s.setQuery(s.draft)
+	case "ctrl+r":
+		return s.reverseMatch(s.reverseIdx + 1)
+	case "backspace":
+		if s.reverseQuery != "" {
+			runes := []rune(s.reverseQuery)
+			s.reverseQuery = string(runes[:len(runes)-1])
+		}
+		return s.reverseMatch(0)
+	}
+
+	if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+		s.reverseQuery += string(msg.Runes)
+		return s.reverseMatch(0)
+	}
+	return nil
+}
+
+// reverseMatch shows the first query of the history, from index from on,
+// containing the reverse search query.
+func (s *searchPage) reverseMatch(from int) tea.Cmd {
+	needle := strings.ToLower(s.reverseQuery)
+	for i := max(from, 0); i < len(s.history); i++ {
+		if strings.Contains(strings.ToLower(s.history[i]), needle) {
+			s.reverseIdx = i
+			return s.setQuery(s.history[i])
+		}
+	}
+	if from == 0 {
+		s.reverseIdx = -1
+	}
+	return nil
+}
+
+func (s searchPage) reverseView() string {
+	label := "(reverse-i-search)"
+	if s.reverseIdx < 0 && s.reverseQuery != "" {
+		label = "(failed reverse-i-search)"
+	}
+	return style.HelperStyle.Action.Render(label) + " " +
+		style.Document.Primary.Render(fmt.Sprintf("`%s'", s.reverseQuery))
+}
+
+// suggestions returns the recent queries extending the typed query, then
+// the queries completing its last word with the title of a record.
+func (s searchPage) suggestions() []string {
+	value := s.field.Value()
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+
+	var suggestions []string
+	add := func(suggestion string) bool {
+		if suggestion != value && !slices.Contains(suggestions, suggestion) {
+			suggestions = append(suggestions, suggestion)
+		}
+		return len(suggestions) < searchSuggestionsMax
+	}
+
+	lower := strings.ToLower(value)
+	for _, q := range s.history {
+		if strings.HasPrefix(strings.ToLower(q), lower) && !add(q) {
+			return suggestions
+		}
+	}
+
+	word := value[strings.LastIndexAny(value, " \t")+1:]
+	if word == "" || strings.ContainsAny(word, `:"`) {
+		return suggestions
+	}
+	prefix := value[:len(value)-len(word)]
+	for _, title := range s.titles() {
+		if !strings.Contains(strings.ToLower(title), strings.ToLower(word)) {
+			continue
+		}
+		if strings.ContainsAny(title, " \t") {
+			title = `"` + strings.ReplaceAll(title, `"`, "") + `"`
+		}
+		if !add(prefix + title) {
+			break
+		}
+	}
+
+	return suggestions
+}
+
+// titles returns the titles of the records at hand, the incremental results
+// and the records of the list the search was opened from.
+func (s searchPage) titles() []string {
+	var titles []string
+	for _, r := range s.results {
+		titles = append(titles, r.GetTitle())
+	}
+	if l, ok := s.prev.(listPage); ok {
+		for _, r := range l.selection.records {
+			titles = append(titles, r.GetTitle())
+		}
+	}
+	return titles
+}
+
+// nextSuggestion fills the field with the following suggestion, the
+// suggestions of the query typed before the first tab are cycled through.
+func (s *searchPage) nextSuggestion() tea.Cmd {
+	if s.suggested == nil {
+		s.suggested = s.suggestions()
+		s.suggestIdx = -1
+		s.draft = s.field.Value()
+	}
+	if len(s.suggested) == 0 {
+		s.suggested = nil
+		return nil
+	}
+
+	s.suggestIdx = (s.suggestIdx + 1) % len(s.suggested)
+	return s.setQuery(s.suggested[s.suggestIdx])
+}
+
+func (s searchPage) suggestionsView(width int) string {
+	suggestions, selected := s.suggested, s.suggestIdx
+	if suggestions == nil {
+		suggestions, selected = s.suggestions(), -1
+	}
+	if len(suggestions) == 0 || s.cursor >= 0 || s.reverse {
+		return ""
+	}
+
+	rows := make([]string, len(suggestions))
+	for i, suggestion := range suggestions {
+		st := style.Document.NormalDim
+		if i == selected {
+			st = style.Document.Highlight
+		}
+		rows[i] = st.Render(suggestion)
+	}
+
+	return lipgloss.NewStyle().Width(width).Padding(0, 1).Render(
+		lipgloss.JoinVertical(lipgloss.Left, rows...),
+	)
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"errors"
 	"log/slog"
+	"maps"
 	"slices"
 	"strings"
 
@@ -10,6 +11,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/liuminhaw/yatijapp-tui/internal/data"
+	"github.com/liuminhaw/yatijapp-tui/internal/datetime"
+	"github.com/liuminhaw/yatijapp-tui/internal/query"
 	"github.com/liuminhaw/yatijapp-tui/internal/style"
 	"github.com/liuminhaw/yatijapp-tui/pkg/strview"
 )
@@ -55,7 +58,12 @@ func newSearchListPage(
 		prev:        prev,
 		popupModels: []tea.Model{},
 	}
-	s.selection.query["search"] = q
+	if parsed, err := query.Parse(q, datetime.Now()); err == nil {
+		maps.Copy(s.selection.query, parsed.QueryStrings())
+		s.selection.match = queryMatch(parsed)
+	} else {
+		s.selection.query["search"] = q
+	}
 
 	return s
 }
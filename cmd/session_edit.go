@@ -0,0 +1,387 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/liuminhaw/yatijapp-tui/internal/authclient"
+	"github.com/liuminhaw/yatijapp-tui/internal/data"
+	"github.com/liuminhaw/yatijapp-tui/internal/datetime"
+	"github.com/liuminhaw/yatijapp-tui/internal/hook"
+	"github.com/liuminhaw/yatijapp-tui/internal/model"
+)
+
+// markedGlyph replaces the status mark of sessions marked for merging.
+const markedGlyph = "◆"
+
+// sessionUndo describes how to revert a split or merge: sessions to restore
+// to their previous state, sessions to create again and sessions to remove.
+type sessionUndo struct {
+	label    string
+	restore  []data.Session
+	recreate []data.Session
+	remove   []string
+}
+
+type sessionMergePreviewMsg struct {
+	sessions []data.Session
+}
+
+// sessionRequest returns the request body setting every field of the session.
+func sessionRequest(s data.Session) data.SessionRequestBody {
+	startsAt := s.StartsAt
+	notes := s.Notes
+	return data.SessionRequestBody{
+		ActionUUID: s.ActionUUID,
+		StartsAt:   &startsAt,
+		EndsAt:     s.EndsAt,
+		Notes:      &notes,
+	}
+}
+
+// validateSplitTime checks that the split time falls within the session, and
+// not in the future for sessions still in progress.
+func validateSplitTime(session data.Session, splitAt, now time.Time) error {
+	start, end := sessionInterval(session.StartsAt, session.EndsAt)
+	if !session.EndsAt.Valid {
+		end = now
+	}
+
+	if !splitAt.After(start) || !splitAt.Before(end) {
+		return errors.New("split time must be within the session")
+	}
+	return nil
+}
+
+// splitSession ends the session at the split time and creates a session for
+// the rest of it, under the action given in the request data.
+func splitSession(
+	serverURL string,
+	d recordRequestData,
+	src, redirect tea.Model,
+	client *authclient.AuthClient,
+) tea.Cmd {
+	return func() tea.Msg {
+		before, err := data.GetSession(serverURL, d.uuid, client)
+		if err != nil {
+			return err
+		}
+
+		first := data.SessionRequestBody{
+			ActionUUID: before.ActionUUID,
+			StartsAt:   &before.StartsAt,
+			EndsAt:     sql.NullTime{Valid: true, Time: d.startsAt},
+		}
+		after, err := first.Update(serverURL, before.UUID, client)
+		if err != nil {
+			return err
+		}
+
+		events := updatedEvents(before, after)
+		second := data.SessionRequestBody{
+			ActionUUID: d.actionUUID,
+			StartsAt:   &d.startsAt,
+			EndsAt:     before.EndsAt,
+		}
+		created, err := second.Create(serverURL, client)
+		if err != nil {
+			return incompleteSessionEdit("Session split", err, events, sessionUndo{
+				label:   "split",
+				restore: []data.Session{before},
+			}, src, redirect)
+		}
+
+		events = append(events, recordEvent(hook.SessionStarted, nil, created))
+		if created.EndsAt.Valid {
+			events = append(events, recordEvent(hook.SessionEnded, nil, created))
+		}
+
+		return apiSuccessResponseMsg{
+			msg:      "Session split at " + datetime.Display().Clock(d.startsAt),
+			source:   src,
+			redirect: redirect,
+			events:   events,
+			undo: &sessionUndo{
+				label:   "split",
+				restore: []data.Session{before},
+				remove:  []string{created.UUID},
+			},
+		}
+	}
+}
+
+// previewSessionMerge loads the sessions marked for merging and checks that
+// they can be merged, they must belong to the same action, follow each other
+// with no other session of the action in between and all but the last one
+// must have ended.
+func previewSessionMerge(
+	serverURL string,
+	uuids []string,
+	client *authclient.AuthClient,
+) tea.Cmd {
+	return func() tea.Msg {
+		if len(uuids) < 2 {
+			return errors.New("mark at least two sessions to merge")
+		}
+
+		sessions := make([]data.Session, 0, len(uuids))
+		for _, uuid := range uuids {
+			session, err := data.GetSession(serverURL, uuid, client)
+			if err != nil {
+				return err
+			}
+			sessions = append(sessions, session)
+		}
+		slices.SortFunc(sessions, func(a, b data.Session) int {
+			return a.StartsAt.Compare(b.StartsAt)
+		})
+
+		for i, session := range sessions {
+			if session.ActionUUID != sessions[0].ActionUUID {
+				return errors.New("only sessions of the same action can be merged")
+			}
+			if i < len(sessions)-1 && !session.EndsAt.Valid {
+				return errors.New("only the latest merged session can be in progress")
+			}
+		}
+
+		if err := checkSessionsAdjacent(serverURL, sessions, client); err != nil {
+			return err
+		}
+
+		return sessionMergePreviewMsg{sessions: sessions}
+	}
+}
+
+// checkSessionsAdjacent looks for sessions of the same action starting in
+// between the sorted sessions.
+func checkSessionsAdjacent(
+	serverURL string,
+	sessions []data.Session,
+	client *authclient.AuthClient,
+) error {
+	first, last := sessions[0], sessions[len(sessions)-1]
+
+	for page := 1; page <= overlapMaxPages; page++ {
+		resp, err := data.ListSessions(data.ListRequestInfo{
+			ServerURL: serverURL,
+			SrcUUID:   first.ActionUUID,
+			QueryStrings: map[string]string{
+				"sort":      "-starts_at",
+				"page":      strconv.Itoa(page),
+				"page_size": strconv.Itoa(overlapPageSize),
+			},
+		}, client)
+		if err != nil {
+			return err
+		}
+
+		for _, other := range resp.Sessions {
+			if other.StartsAt.Before(first.StartsAt) {
+				return nil
+			}
+			if other.StartsAt.After(last.StartsAt) ||
+				slices.ContainsFunc(sessions, func(s data.Session) bool { return s.UUID == other.UUID }) {
+				continue
+			}
+			return fmt.Errorf(
+				"session at %s is in between, sessions must be adjacent",
+				datetime.Display().DateTime(other.StartsAt),
+			)
+		}
+		if len(resp.Sessions) < overlapPageSize || page >= resp.Metadata.LastPage {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// mergedNotes joins the notes of the sessions, skipping empty ones.
+func mergedNotes(sessions []data.Session) string {
+	var notes []string
+	for _, session := range sessions {
+		if note := strings.TrimSpace(session.Notes); note != "" {
+			notes = append(notes, note)
+		}
+	}
+
+	return strings.Join(notes, "\n\n")
+}
+
+func sessionMergeAlert(sessions []data.Session, merge tea.Cmd) tea.Model {
+	f := datetime.Display()
+	first, last := sessions[0], sessions[len(sessions)-1]
+
+	end := "now"
+	if last.EndsAt.Valid {
+		end = f.DateTime(last.EndsAt.Time)
+	}
+
+	var tracked time.Duration
+	for _, session := range sessions {
+		start, end := sessionInterval(session.StartsAt, session.EndsAt)
+		tracked += end.Sub(start)
+	}
+	start, finish := sessionInterval(first.StartsAt, last.EndsAt)
+
+	prompts := []string{
+		fmt.Sprintf("Merge %d sessions of \"%s\" into", len(sessions), first.ActionTitle),
+		f.DateTime(first.StartsAt) + " → " + end,
+		"",
+		fmt.Sprintf(
+			"Tracked %s, merged %s",
+			shortDuration(tracked.Round(time.Minute)),
+			shortDuration(finish.Sub(start).Round(time.Minute)),
+		),
+	}
+	if notes := mergedNotes(sessions); notes != "" {
+		lines := strings.Count(notes, "\n") + 1
+		prompts = append(prompts, fmt.Sprintf("Notes joined into %d line(s)", lines))
+	}
+
+	return model.NewAlert(
+		"Confirm Merge",
+		"confirmation",
+		prompts,
+		[]string{fmt.Sprintf("%d session(s) will be deleted, press 'u' to undo.", len(sessions)-1)},
+		60,
+		map[string]tea.Cmd{"confirm": merge, "cancel": cancelPopupCmd},
+	)
+}
+
+// mergeSessions extends the first of the sorted sessions to the end of the
+// last one with the notes of all of them, and deletes the others.
+func mergeSessions(
+	serverURL string,
+	sessions []data.Session,
+	src, redirect tea.Model,
+	client *authclient.AuthClient,
+) tea.Cmd {
+	return func() tea.Msg {
+		first, last := sessions[0], sessions[len(sessions)-1]
+
+		merged := first
+		merged.EndsAt = last.EndsAt
+		merged.Notes = mergedNotes(sessions)
+		after, err := sessionRequest(merged).Update(serverURL, first.UUID, client)
+		if err != nil {
+			return err
+		}
+
+		events := updatedEvents(first, after)
+		for i, session := range sessions[1:] {
+			if err := data.DeleteSession(serverURL, session.UUID, client); err != nil {
+				return incompleteSessionEdit("Session merge", err, events, sessionUndo{
+					label:    "merge",
+					restore:  []data.Session{first},
+					recreate: sessions[1 : i+1],
+				}, src, redirect)
+			}
+			events = append(events, deletedEvent(data.RecordTypeSession, session.UUID, session))
+		}
+
+		return apiSuccessResponseMsg{
+			msg:      fmt.Sprintf("%d sessions merged", len(sessions)),
+			source:   src,
+			redirect: redirect,
+			events:   events,
+			undo: &sessionUndo{
+				label:    "merge",
+				restore:  []data.Session{first},
+				recreate: sessions[1:],
+			},
+		}
+	}
+}
+
+// incompleteSessionEdit reports a split, merge or undo which failed after
+// some of its requests went through, keeping the undo of what is left to
+// revert.
+func incompleteSessionEdit(
+	what string,
+	err error,
+	events []hook.Event,
+	undo sessionUndo,
+	src, redirect tea.Model,
+) apiSuccessResponseMsg {
+	return apiSuccessResponseMsg{
+		msg:      fmt.Sprintf("%s incomplete: %s, press 'u' to undo", what, apiErrorText(err)),
+		source:   src,
+		redirect: redirect,
+		events:   events,
+		undo:     &undo,
+	}
+}
+
+// undoSessionEdit reverts the last split or merge. Sessions created again get
+// new uuids. When a step fails, the undo of the steps left is kept.
+func undoSessionEdit(
+	serverURL string,
+	undo sessionUndo,
+	src, redirect tea.Model,
+	client *authclient.AuthClient,
+) tea.Cmd {
+	return func() tea.Msg {
+		var events []hook.Event
+		incomplete := func(err error, left sessionUndo) tea.Msg {
+			if len(events) == 0 {
+				return err
+			}
+			return incompleteSessionEdit("Undo of session "+undo.label, err, events, left, src, redirect)
+		}
+
+		for i, uuid := range undo.remove {
+			if err := data.DeleteSession(serverURL, uuid, client); err != nil {
+				return incomplete(err, sessionUndo{
+					label:    undo.label,
+					restore:  undo.restore,
+					recreate: undo.recreate,
+					remove:   undo.remove[i:],
+				})
+			}
+			events = append(events, deletedEvent(data.RecordTypeSession, uuid, nil))
+		}
+
+		for i, session := range undo.restore {
+			left := sessionUndo{
+				label:    undo.label,
+				restore:  undo.restore[i:],
+				recreate: undo.recreate,
+			}
+			before, err := data.GetSession(serverURL, session.UUID, client)
+			if err != nil {
+				return incomplete(err, left)
+			}
+			after, err := sessionRequest(session).Update(serverURL, session.UUID, client)
+			if err != nil {
+				return incomplete(err, left)
+			}
+			events = append(events, updatedEvents(before, after)...)
+		}
+
+		for i, session := range undo.recreate {
+			created, err := sessionRequest(session).Create(serverURL, client)
+			if err != nil {
+				return incomplete(err, sessionUndo{
+					label:    undo.label,
+					recreate: undo.recreate[i:],
+				})
+			}
+			events = append(events, recordEvent(hook.SessionStarted, nil, created))
+		}
+
+		return apiSuccessResponseMsg{
+			msg:      "Session " + undo.label + " undone",
+			source:   src,
+			redirect: redirect,
+			events:   events,
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/liuminhaw/yatijapp-tui/internal/authclient"
+	"github.com/liuminhaw/yatijapp-tui/internal/data"
+	"github.com/liuminhaw/yatijapp-tui/internal/datetime"
+	"github.com/liuminhaw/yatijapp-tui/internal/hook"
+	"github.com/liuminhaw/yatijapp-tui/internal/model"
+)
+
+const (
+	overlapPageSize = 50
+	overlapMaxPages = 10
+	// overlapLookback is how long before the session start neighbours are
+	// searched for when there is no maximum session duration.
+	overlapLookback = 24 * time.Hour
+)
+
+type (
+	sessionOverlapMsg struct {
+		d        recordRequestData
+		overlaps []data.Session
+	}
+	sessionOverlapTrimMsg   struct{}
+	sessionOverlapSaveMsg   struct{}
+	sessionOverlapCancelMsg struct{}
+)
+
+// sessionInterval returns the interval of the session, open sessions last
+// until now.
+func sessionInterval(startsAt time.Time, endsAt sql.NullTime) (time.Time, time.Time) {
+	if endsAt.Valid {
+		return startsAt, endsAt.Time
+	}
+	return startsAt, time.Now()
+}
+
+func sessionsOverlap(a, b data.Session) bool {
+	aStart, aEnd := sessionInterval(a.StartsAt, a.EndsAt)
+	bStart, bEnd := sessionInterval(b.StartsAt, b.EndsAt)
+	return aStart.Before(bEnd) && bStart.Before(aEnd)
+}
+
+// checkSessionOverlap fetches the sessions around the interval of the
+// session to save and reports the ones colliding with it. Sessions are
+// fetched latest first, until they start earlier than the lookback.
+func checkSessionOverlap(
+	serverURL string,
+	d recordRequestData,
+	lookback time.Duration,
+	client *authclient.AuthClient,
+) tea.Cmd {
+	if lookback <= 0 {
+		lookback = overlapLookback
+	}
+
+	return func() tea.Msg {
+		session := data.Session{UUID: d.uuid, StartsAt: d.startsAt, EndsAt: d.endsAt}
+		_, end := sessionInterval(d.startsAt, d.endsAt)
+		earliest := d.startsAt.Add(-lookback)
+
+		var overlaps []data.Session
+		for page := 1; page <= overlapMaxPages; page++ {
+			resp, err := data.ListSessions(data.ListRequestInfo{
+				ServerURL: serverURL,
+				QueryStrings: map[string]string{
+					"sort":      "-starts_at",
+					"page":      strconv.Itoa(page),
+					"page_size": strconv.Itoa(overlapPageSize),
+				},
+			}, client)
+			if err != nil {
+				return err
+			}
+
+			done := len(resp.Sessions) < overlapPageSize ||
+				page >= resp.Metadata.LastPage
+			for _, other := range resp.Sessions {
+				if other.StartsAt.Before(earliest) {
+					done = true
+					break
+				}
+				if other.UUID == d.uuid || !other.StartsAt.Before(end) {
+					continue
+				}
+				if sessionsOverlap(session, other) {
+					overlaps = append(overlaps, other)
+				}
+			}
+			if done {
+				break
+			}
+		}
+
+		return sessionOverlapMsg{d: d, overlaps: overlaps}
+	}
+}
+
+// validateSessionInterval checks that the session ends after it starts, not
+// in the future and within the maximum duration if there is one.
+func validateSessionInterval(startsAt, endsAt, now time.Time, maxDuration time.Duration) error {
+	switch {
+	case !endsAt.After(startsAt):
+		return errors.New("ends at must be after starts at")
+	case endsAt.After(now):
+		return errors.New("ends at can not be in the future")
+	case maxDuration > 0 && endsAt.Sub(startsAt) > maxDuration:
+		return fmt.Errorf("session can not be longer than %s", shortDuration(maxDuration))
+	}
+
+	return nil
+}
+
+// overlapTrims returns the updates trimming the overlapping sessions to the
+// edges of the saved session. Sessions can only be trimmed if they stick out
+// on one side of the saved session, false is returned otherwise.
+func overlapTrims(d recordRequestData, overlaps []data.Session) ([]data.Session, bool) {
+	start, end := sessionInterval(d.startsAt, d.endsAt)
+
+	trims := make([]data.Session, 0, len(overlaps))
+	for _, other := range overlaps {
+		otherStart, otherEnd := sessionInterval(other.StartsAt, other.EndsAt)
+		switch {
+		case otherStart.Before(start) && !otherEnd.After(end):
+			other.EndsAt = sql.NullTime{Valid: true, Time: start}
+		case !otherStart.Before(start) && otherEnd.After(end) && d.endsAt.Valid && other.EndsAt.Valid:
+			other.StartsAt = end
+		default:
+			return nil, false
+		}
+		trims = append(trims, other)
+	}
+
+	return trims, true
+}
+
+func sessionOverlapAlert(overlaps []data.Session, trimmable bool) tea.Model {
+	f := datetime.Display()
+
+	prompts := []string{
+		fmt.Sprintf("The session overlaps with %d other session(s):", len(overlaps)),
+		"",
+	}
+	for i, other := range overlaps {
+		if i == 3 {
+			prompts = append(prompts, fmt.Sprintf("and %d more", len(overlaps)-i))
+			break
+		}
+		end := "--"
+		if other.EndsAt.Valid {
+			end = f.DateTime(other.EndsAt.Time)
+		}
+		prompts = append(prompts, fmt.Sprintf(
+			"%s: %s → %s", other.ActionTitle, f.DateTime(other.StartsAt), end,
+		))
+	}
+
+	var warnings []string
+	options := []model.AlertOption{}
+	if trimmable {
+		options = append(options, model.AlertOption{
+			Key: "t", Label: "trim others", Cmd: func() tea.Msg { return sessionOverlapTrimMsg{} },
+		})
+	} else {
+		warnings = append(warnings, "Sessions within this session can not be trimmed")
+	}
+	options = append(options,
+		model.AlertOption{
+			Key: "s", Label: "save anyway", Cmd: func() tea.Msg { return sessionOverlapSaveMsg{} },
+		},
+		model.AlertOption{
+			Key: "c", Label: "cancel", Cmd: func() tea.Msg { return sessionOverlapCancelMsg{} },
+		},
+	)
+
+	return model.NewOptionsAlert("Overlapping sessions", prompts, warnings, 70, options)
+}
+
+// trimAndSaveSession trims the overlapping sessions before saving the
+// session with the save command.
+func trimAndSaveSession(
+	serverURL string,
+	trims []data.Session,
+	save tea.Cmd,
+	client *authclient.AuthClient,
+) tea.Cmd {
+	return func() tea.Msg {
+		var events []hook.Event
+		for _, trim := range trims {
+			before, err := data.GetSession(serverURL, trim.UUID, client)
+			if err != nil {
+				return err
+			}
+
+			startsAt := trim.StartsAt
+			request := data.SessionRequestBody{
+				ActionUUID: trim.ActionUUID,
+				StartsAt:   &startsAt,
+				EndsAt:     trim.EndsAt,
+			}
+			after, err := request.Update(serverURL, trim.UUID, client)
+			if err != nil {
+				return err
+			}
+			events = append(events, updatedEvents(before, after)...)
+		}
+
+		msg := save()
+		if success, ok := msg.(apiSuccessResponseMsg); ok {
+			success.msg = fmt.Sprintf("%s, %d overlapping session(s) trimmed", success.msg, len(trims))
+			success.events = append(events, success.events...)
+			return success
+		}
+		return msg
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/liuminhaw/yatijapp-tui/internal/activity"
 	"github.com/liuminhaw/yatijapp-tui/internal/data"
 	"github.com/liuminhaw/yatijapp-tui/internal/model"
 	"github.com/liuminhaw/yatijapp-tui/internal/style"
@@ -171,11 +172,12 @@ func (m signinPage) signin() tea.Cmd {
 		if err := request.Signin(m.cfg.apiEndpoint, m.cfg.authClient.TokenPath); err != nil {
 			return err
 		}
+		recordAccountActivity(m.cfg, activity.SignedIn, email)
 
 		return apiSuccessResponseMsg{
 			msg:      "Signed in successfully",
 			source:   m,
-			redirect: newMenuPage(m.cfg, m.width, m.height),
+			redirect: newHomePage(m.cfg, m.width, m.height),
 		}
 	}
 }
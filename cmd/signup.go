@@ -7,6 +7,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/liuminhaw/yatijapp-tui/internal/activity"
 	"github.com/liuminhaw/yatijapp-tui/internal/data"
 	"github.com/liuminhaw/yatijapp-tui/internal/model"
 	"github.com/liuminhaw/yatijapp-tui/internal/style"
@@ -342,11 +343,12 @@ func (m signupPage) activate() tea.Cmd {
 				redirect: newMenuPage(m.cfg, m.width, m.height),
 			}
 		}
+		recordAccountActivity(m.cfg, activity.SignedIn, email)
 
 		return apiSuccessResponseMsg{
 			msg:      "Account activated",
 			source:   m,
-			redirect: newMenuPage(m.cfg, m.width, m.height),
+			redirect: newHomePage(m.cfg, m.width, m.height),
 		}
 	}
 }
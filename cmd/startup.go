@@ -9,6 +9,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/liuminhaw/yatijapp-tui/colors"
+	"github.com/liuminhaw/yatijapp-tui/internal/activity"
 	"github.com/liuminhaw/yatijapp-tui/internal/components"
 	"github.com/liuminhaw/yatijapp-tui/internal/data"
 	"github.com/liuminhaw/yatijapp-tui/internal/style"
@@ -39,8 +40,8 @@ func menuAuthView(name string) *authView {
 	return &authView{
 		name: "menu",
 		view: menuView([][]string{
-			{"Targets", "Actions", "Sessions", "", "Sign out"},
-			{"Preferences"},
+			{"Dashboard", "Targets", "Actions", "Sessions", "", "Sign out"},
+			{"Views", "Activity", "Stats", "Preferences"},
 		}),
 		page:     0,
 		greeting: fmt.Sprintf("Welcome, %s", name),
@@ -87,6 +88,10 @@ type menuPage struct {
 	spinner spinner.Model
 	loading bool
 
+	// home switches to the dashboard once the user is loaded, the menu is
+	// only passed through after signing in.
+	home bool
+
 	popupModels []tea.Model
 	popup       string
 
@@ -111,6 +116,13 @@ func newMenuPage(cfg config, width, height int) menuPage {
 	return page
 }
 
+func newHomePage(cfg config, width, height int) menuPage {
+	page := newMenuPage(cfg, width, height)
+	page.home = true
+
+	return page
+}
+
 func (m menuPage) loadLoginUser() tea.Cmd {
 	return func() tea.Msg {
 		user, err := data.GetCurrentUser(m.cfg.apiEndpoint, m.cfg.authClient)
@@ -118,11 +130,7 @@ func (m menuPage) loadLoginUser() tea.Cmd {
 			return err
 		}
 
-		return apiSuccessResponseMsg{
-			msg:      user.Name,
-			source:   m,
-			redirect: m,
-		}
+		return loginUserLoadedMsg{user: user}
 	}
 }
 
@@ -149,6 +157,8 @@ func (m menuPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			switch m.view.name {
 			case "menu":
 				switch selected {
+				case "Dashboard":
+					return m, switchToDashboardCmd
 				case "Targets":
 					return m, switchToTargetsCmd
 				case "Actions":
@@ -157,6 +167,17 @@ func (m menuPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m, switchToSessionsCmd
 				case "Sign out":
 					return m, m.signout()
+				case "Views":
+					if len(m.cfg.preferences.Views) == 0 {
+						m.msg = "No saved views, press S in a list to save one"
+						return m, nil
+					}
+					m.authView = savedViewsAuthView(m.view, m.cfg.preferences.Views)
+					m.view = m.authView
+				case "Activity":
+					return m, switchToActivityCmd
+				case "Stats":
+					return m, switchToStatsCmd
 				case "Preferences":
 					// m.cfg.logger.Info("switch to preference", "view", fmt.Sprintf("%+v", m.view))
 					m.authView = preferencesAuthView(m.view)
@@ -172,6 +193,10 @@ func (m menuPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				case "Forget password":
 					return m, switchToResetPasswordCmd
 				}
+			case "views":
+				if v, ok := m.cfg.preferences.View(selected); ok {
+					return m, switchToSavedViewCmd(v)
+				}
 			case "preferences":
 				switch selected {
 				case "Filter":
@@ -189,6 +214,10 @@ func (m menuPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 			return m, nil
+		case "d":
+			if m.view.name == "views" {
+				return m.deleteView(m.view.view[m.view.page].Selected())
+			}
 		case "ctrl+/", "ctrl+_":
 			return m, switchToSearchCmd(data.RecordTypeAll)
 		case "<":
@@ -209,6 +238,22 @@ func (m menuPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "up", "down", "k", "j":
 			m.msg = ""
 		}
+	case viewsUpdatedMsg:
+		if msg.err != nil {
+			m.cfg.logger.Error(msg.err.Error(), slog.String("action", "delete view"))
+			m.msg = msg.err.Error()
+			return m, nil
+		}
+		m.msg = msg.msg
+		if m.view.name == "views" {
+			if len(m.cfg.preferences.Views) == 0 {
+				m.authView = m.view.prev
+			} else {
+				m.authView = savedViewsAuthView(m.view.prev, m.cfg.preferences.Views)
+			}
+			m.view = m.authView
+		}
+		return m, nil
 	case showSearchMsg:
 		popupModel := newSearchPage(
 			m.cfg, msg.scope, style.ViewSize{Width: m.width, Height: m.height}, "", m,
@@ -218,36 +263,46 @@ func (m menuPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case switchToPreviousMsg:
 		m.loading = true
 		return m, tea.Batch(m.spinner.Tick, m.loadLoginUser())
-	case apiSuccessResponseMsg:
-		if isExactType[menuPage](msg.source) {
-			preferences, err := data.GetPreferences(m.cfg.apiEndpoint, m.cfg.authClient)
-			if err != nil {
-				var ne data.NotFoundApiDataErr
-				if errors.As(err, &ne) {
-					m.cfg.logger.Info("no user preferences found, load default preferences")
-					preferences = data.DefaultPreferences
-				} else {
-					m.cfg.logger.Error("failed to load user preferences")
-					m.error = err
-					return m, cmd
-				}
+	case loginUserLoadedMsg:
+		preferences, err := data.GetPreferences(m.cfg.apiEndpoint, m.cfg.authClient)
+		if err != nil {
+			var ne data.NotFoundApiDataErr
+			if errors.As(err, &ne) {
+				m.cfg.logger.Info("no user preferences found, load default preferences")
+				preferences = data.DefaultPreferences
+			} else {
+				m.cfg.logger.Error("failed to load user preferences")
+				m.error = err
+				return m, cmd
 			}
-			m.cfg.preferences = &preferences
-			m.cfg.logger.Info(
-				"loaded user preferences",
-				slog.Any("preferences", fmt.Sprintf("preferences: %+v", preferences)),
-			)
+		}
+		m.cfg.preferences = &preferences
+		m.cfg.userName = msg.user.Name
+		m.cfg.userEmail = msg.user.Email
+		m.cfg.logger.Info(
+			"loaded user preferences",
+			slog.Any("preferences", fmt.Sprintf("preferences: %+v", preferences)),
+		)
 
-			m.authView = menuAuthView(msg.msg)
-			m.view = m.authView
-			m.view.page = 0
-			m.loading = false
-			return m, obtainPreferencesCmd(preferences)
-		} else {
-			m.cfg.logger.Info("menu page receive api success response from other source")
-			m.msg = msg.msg
-			return m, m.loadLoginUser()
+		m.authView = menuAuthView(msg.user.Name)
+		m.view = m.authView
+		m.view.page = 0
+		m.loading = false
+		if m.home {
+			m.home = false
+			return m, tea.Sequence(
+				obtainPreferencesCmd(m.cfg.preferences),
+				obtainUserCmd(msg.user),
+				switchToDashboardCmd,
+			)
 		}
+		return m, tea.Batch(obtainPreferencesCmd(m.cfg.preferences), obtainUserCmd(msg.user))
+	case apiSuccessResponseMsg:
+		m.msg = msg.msg
+		return m, m.loadLoginUser()
+	case signedOutMsg:
+		m.msg = "sign out successfully"
+		return m, m.loadLoginUser()
 	case data.UnauthorizedApiDataErr:
 		m.cfg.logger.Error(msg.Err.Error(), slog.String("action", "load login user"))
 		m.view = m.unauthView
@@ -277,6 +332,12 @@ func (m menuPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+func (m menuPage) closePopups() tea.Model {
+	m.popupModels = []tea.Model{}
+	m.popup = ""
+	return m
+}
+
 func (m menuPage) View() string {
 	if m.error != nil {
 		container := lipgloss.JoinVertical(
@@ -339,6 +400,9 @@ func (m menuPage) View() string {
 		helper = append(helper, style.HelperContent{Key: "←", Action: "back"})
 	}
 
+	if m.view.name == "views" {
+		helper = append(helper, style.HelperContent{Key: "d", Action: "delete"})
+	}
 	if m.view.prev != nil {
 		helper = append(helper, style.HelperContent{Key: "<", Action: "back"})
 	}
@@ -374,18 +438,33 @@ func (m menuPage) View() string {
 	return container
 }
 
+// deleteView removes the saved view from the preferences. The menu lists the
+// views again once the preferences are updated.
+func (m menuPage) deleteView(name string) (menuPage, tea.Cmd) {
+	if _, ok := m.cfg.preferences.View(name); !ok {
+		return m, nil
+	}
+	preferences := m.cfg.preferences.Clone()
+	preferences.DeleteView(name)
+
+	return m, updateViewsCmd(
+		m.cfg.apiEndpoint,
+		preferences,
+		"",
+		fmt.Sprintf("View %q deleted", name),
+		m.cfg.authClient,
+	)
+}
+
 func (m menuPage) signout() tea.Cmd {
 	return func() tea.Msg {
 		_, err := data.Signout(m.cfg.apiEndpoint, m.cfg.authClient)
 		if err != nil {
 			return err
 		}
+		recordAccountActivity(m.cfg, activity.SignedOut, m.cfg.userEmail)
 
-		return apiSuccessResponseMsg{
-			msg:      "sign out successfully",
-			source:   Water{},
-			redirect: m,
-		}
+		return signedOutMsg{redirect: m}
 	}
 }
 
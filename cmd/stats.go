@@ -0,0 +1,394 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/liuminhaw/yatijapp-tui/colors"
+	"github.com/liuminhaw/yatijapp-tui/internal/authclient"
+	"github.com/liuminhaw/yatijapp-tui/internal/data"
+	"github.com/liuminhaw/yatijapp-tui/internal/datetime"
+	"github.com/liuminhaw/yatijapp-tui/internal/stats"
+	"github.com/liuminhaw/yatijapp-tui/internal/style"
+)
+
+const (
+	statsPageSize = 100
+	statsMaxPages = 40
+	statsBarWidth = 18
+	// statsLookback is how long before the start of the heatmap the
+	// sessions are paged back to, for the sessions running into it.
+	statsLookback = 24 * time.Hour
+)
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+type statsFetchedMsg struct {
+	snapshot stats.Snapshot
+	err      error
+}
+
+// fetchStatsIntervals pages through the sessions back to the start of the
+// heatmap, saving them to the cache of the profile.
+func fetchStatsIntervals(cfg config, serverURL string, client *authclient.AuthClient) tea.Cmd {
+	return func() tea.Msg {
+		now := time.Now()
+		earliest := stats.Start(datetime.Now()).Add(-statsLookback)
+
+		snapshot := stats.Snapshot{FetchedAt: now}
+		for page := 1; page <= statsMaxPages; page++ {
+			resp, err := data.ListSessions(data.ListRequestInfo{
+				ServerURL: serverURL,
+				QueryStrings: map[string]string{
+					"sort":      "-starts_at",
+					"page":      strconv.Itoa(page),
+					"page_size": strconv.Itoa(statsPageSize),
+				},
+			}, client)
+			if err != nil {
+				return statsFetchedMsg{err: err}
+			}
+
+			done := len(resp.Sessions) < statsPageSize || page >= resp.Metadata.LastPage
+			for _, session := range resp.Sessions {
+				if session.StartsAt.Before(earliest) {
+					done = true
+					break
+				}
+				start, end := sessionInterval(session.StartsAt, session.EndsAt)
+				snapshot.Intervals = append(snapshot.Intervals, stats.Interval{
+					Start:       start,
+					End:         end,
+					TargetUUID:  session.TargetUUID,
+					TargetTitle: session.TargetTitle,
+				})
+			}
+			if done {
+				break
+			}
+		}
+
+		if err := cfg.statsCache.Save(profileKey(cfg), snapshot); err != nil {
+			cfg.logger.Error(err.Error(), slog.String("action", "save stats cache"))
+		}
+		return statsFetchedMsg{snapshot: snapshot}
+	}
+}
+
+// statsPage shows the heatmap, streaks and distributions of the tracked
+// time. The cached sessions show up at once while they are fetched again.
+type statsPage struct {
+	cfg config
+
+	snapshot stats.Snapshot
+	targets  []stats.Target
+	target   int // index of the filtered target, -1 for all targets
+	stats    stats.Stats
+
+	spinner spinner.Model
+	loading bool
+	err     error
+
+	width  int
+	height int
+
+	prev tea.Model
+}
+
+func newStatsPage(cfg config, termSize style.ViewSize, prev tea.Model) statsPage {
+	snapshot, err := cfg.statsCache.Load(profileKey(cfg))
+	if err != nil {
+		cfg.logger.Error(err.Error(), slog.String("action", "load stats cache"))
+	}
+
+	p := statsPage{
+		cfg:     cfg,
+		target:  -1,
+		spinner: spinner.New(spinner.WithSpinner(spinner.Line)),
+		loading: true,
+		width:   termSize.Width,
+		height:  termSize.Height,
+		prev:    prev,
+	}
+	p.setSnapshot(snapshot)
+
+	return p
+}
+
+func (p statsPage) Init() tea.Cmd {
+	return tea.Batch(p.spinner.Tick, fetchStatsIntervals(p.cfg, p.cfg.apiEndpoint, p.cfg.authClient))
+}
+
+// setSnapshot computes the statistics of the snapshot, keeping the filtered
+// target when it is still there.
+func (p *statsPage) setSnapshot(snapshot stats.Snapshot) {
+	var uuid string
+	if p.target >= 0 {
+		uuid = p.targets[p.target].UUID
+	}
+
+	p.snapshot = snapshot
+	p.targets = stats.Targets(snapshot.Intervals)
+	p.target = -1
+	for i, t := range p.targets {
+		if uuid != "" && t.UUID == uuid {
+			p.target = i
+		}
+	}
+	p.compute()
+}
+
+func (p *statsPage) compute() {
+	var uuid string
+	if p.target >= 0 {
+		uuid = p.targets[p.target].UUID
+	}
+	p.stats = stats.Compute(p.snapshot.Intervals, uuid, datetime.Now())
+}
+
+func (p statsPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.width = msg.Width
+		p.height = msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q":
+			return p, tea.Quit
+		case "<", "esc":
+			return p, switchToPreviousCmd(p.prev)
+		case "m":
+			return p, switchToMenuCmd
+		case "t", "right", "l":
+			if len(p.targets) > 0 {
+				p.target = (p.target+2)%(len(p.targets)+1) - 1
+				p.compute()
+			}
+		case "T", "left", "h":
+			if len(p.targets) > 0 {
+				p.target = (p.target+len(p.targets)+1)%(len(p.targets)+1) - 1
+				p.compute()
+			}
+		case "a":
+			p.target = -1
+			p.compute()
+		case "r", "ctrl+r":
+			if p.loading {
+				return p, nil
+			}
+			p.loading = true
+			p.err = nil
+			return p, tea.Batch(
+				p.spinner.Tick,
+				fetchStatsIntervals(p.cfg, p.cfg.apiEndpoint, p.cfg.authClient),
+			)
+		}
+	case statsFetchedMsg:
+		p.loading = false
+		if msg.err != nil {
+			p.cfg.logger.Error(msg.err.Error(), slog.String("action", "fetch stats sessions"))
+			p.err = msg.err
+			return p, nil
+		}
+		p.setSnapshot(msg.snapshot)
+	case spinner.TickMsg:
+		if !p.loading {
+			return p, nil
+		}
+		var cmd tea.Cmd
+		p.spinner, cmd = p.spinner.Update(msg)
+		return p, cmd
+	}
+
+	return p, nil
+}
+
+func (p statsPage) View() string {
+	targetTitle := "All targets"
+	if p.target >= 0 {
+		targetTitle = p.targets[p.target].Title
+	}
+	title := style.TitleBarView([]string{"Stats", targetTitle}, viewWidth, false)
+
+	var status string
+	switch {
+	case p.loading:
+		p.spinner.Style = style.Document.Highlight
+		status = p.spinner.View() + style.Document.NormalDim.Render(" updating sessions...")
+	case p.err != nil:
+		status = style.ErrorStyle.Render("Update failed: " + apiErrorText(p.err))
+	case !p.snapshot.FetchedAt.IsZero():
+		status = style.Document.NormalDim.Render(
+			"Updated " + datetime.Relative(p.snapshot.FetchedAt, time.Now()),
+		)
+	}
+	statusView := lipgloss.NewStyle().Width(viewWidth).Padding(0, 1).Render(status)
+
+	s := p.stats
+	busiest, _ := s.BusiestWeekday()
+	summary := []string{
+		statsFigure("Current streak", fmt.Sprintf("%d days", s.CurrentStreak)),
+		statsFigure("Longest streak", fmt.Sprintf("%d days", s.LongestStreak)),
+		statsFigure("Total", formatTracked(s.Total)),
+	}
+	if s.Total > 0 {
+		summary = append(summary, statsFigure("Busiest day", busiest.String()[:3]))
+	}
+	summaryView := lipgloss.NewStyle().Width(viewWidth).Padding(0, 1).
+		Render(strings.Join(summary, "  "))
+
+	distributions := lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		lipgloss.NewStyle().Width(viewWidth/2).Padding(0, 1).Render(p.weekdaysView()),
+		lipgloss.NewStyle().Width(viewWidth/2).Padding(0, 1).Render(p.hoursView()),
+	)
+
+	helperView := style.HelperView([]style.HelperContent{
+		{Key: "t/T", Action: "next/prev target"},
+		{Key: "a", Action: "all targets"},
+		{Key: "r", Action: "update"},
+		{Key: "<", Action: "back"},
+		{Key: "m", Action: "menu"},
+		{Key: "q", Action: "quit"},
+	}, viewWidth)
+
+	container := lipgloss.JoinVertical(
+		lipgloss.Center,
+		title,
+		statusView,
+		p.heatmapView(),
+		"",
+		summaryView,
+		"",
+		distributions,
+		"",
+		helperView,
+	)
+
+	return style.ContainerStyle(p.width, container, 5).Render(container)
+}
+
+func statsFigure(label, value string) string {
+	return style.Document.NormalDim.Render(label+" ") + style.Document.Highlight.Render(value)
+}
+
+// heatmapView renders a column of days for each week, mondays on top, with
+// the months labeled above.
+func (p statsPage) heatmapView() string {
+	s := p.stats
+	cells := make([]lipgloss.Style, len(colors.Heat))
+	for i, c := range colors.Heat {
+		cells[i] = lipgloss.NewStyle().Foreground(c)
+	}
+
+	months := []rune(strings.Repeat(" ", stats.Weeks))
+	free := 0 // first column free for a month label
+	rows := make([]strings.Builder, 7)
+	for week := range stats.Weeks {
+		monday := s.Day(7 * week)
+		if week == 0 || monday.Month() != s.Day(7*(week-1)).Month() {
+			label := []rune(monday.Format("Jan"))
+			if week >= free && week+len(label) <= stats.Weeks {
+				copy(months[week:], label)
+				free = week + len(label) + 1
+			}
+		}
+
+		for wd := range 7 {
+			i := 7*week + wd
+			if i >= len(s.Days) {
+				rows[wd].WriteString(" ")
+				continue
+			}
+			rows[wd].WriteString(cells[s.Level(s.Days[i])].Render("■"))
+		}
+	}
+
+	labels := []string{"Mon", "", "Wed", "", "Fri", "", "Sun"}
+	lines := []string{"    " + style.Document.NormalDim.Render(string(months))}
+	for wd := range rows {
+		lines = append(lines, style.Document.NormalDim.Render(fmt.Sprintf("%-4s", labels[wd]))+rows[wd].String())
+	}
+
+	legend := style.Document.NormalDim.Render("Less ")
+	for _, cell := range cells {
+		legend += cell.Render("■")
+	}
+	legend += style.Document.NormalDim.Render(" More")
+	lines = append(lines, lipgloss.NewStyle().Width(4+stats.Weeks).AlignHorizontal(lipgloss.Right).Render(legend))
+
+	return strings.Join(lines, "\n")
+}
+
+// weekdaysView renders the tracked time of each weekday as bars.
+func (p statsPage) weekdaysView() string {
+	s := p.stats
+	var most time.Duration
+	for _, d := range s.Weekdays {
+		most = max(most, d)
+	}
+
+	lines := []string{style.Document.Normal.Render("By weekday")}
+	for i := range 7 {
+		wd := time.Weekday((i + 1) % 7)
+		width := 0
+		if most > 0 {
+			width = int(int64(statsBarWidth) * int64(s.Weekdays[wd]) / int64(most))
+		}
+		lines = append(lines, fmt.Sprintf(
+			"%s %s%s %s",
+			style.Document.NormalDim.Render(wd.String()[:3]),
+			style.Document.Primary.Render(strings.Repeat("█", width)),
+			strings.Repeat(" ", statsBarWidth-width),
+			style.Document.NormalDim.Render(formatTracked(s.Weekdays[wd])),
+		))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// hoursView renders the tracked time of each hour of the day as a
+// sparkline.
+func (p statsPage) hoursView() string {
+	s := p.stats
+	var most time.Duration
+	for _, d := range s.Hours {
+		most = max(most, d)
+	}
+
+	var spark strings.Builder
+	for _, d := range s.Hours {
+		level := 0
+		if most > 0 {
+			level = int(int64(len(sparkBlocks)-1) * int64(d) / int64(most))
+		}
+		block := string(sparkBlocks[level])
+		if d == 0 {
+			block = " "
+		}
+		spark.WriteString(block + block)
+	}
+
+	busiest := 0
+	for h, d := range s.Hours {
+		if d > s.Hours[busiest] {
+			busiest = h
+		}
+	}
+
+	lines := []string{
+		style.Document.Normal.Render("By hour of day"),
+		"",
+		style.Document.Primary.Render(spark.String()),
+		style.Document.NormalDim.Render(fmt.Sprintf("%-12s%-12s%-12s%-12s", "0", "6", "12", "18")),
+	}
+	if most > 0 {
+		lines = append(lines, "", statsFigure("Busiest hour", fmt.Sprintf("%02d:00", busiest)))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -22,6 +22,8 @@ type (
 	switchToActionsMsg       struct{ parents data.RecordParents }
 	switchToSessionsMsg      struct{ parents data.RecordParents }
 	switchToMenuMsg          struct{}
+	switchToHomeMsg          struct{}
+	switchToDashboardMsg     struct{}
 	switchToSigninMsg        struct{}
 	switchToSignupMsg        struct{}
 	switchToResetPasswordMsg struct{}
@@ -50,11 +52,18 @@ type (
 	}
 	switchToFilterMsg     struct{ f data.RecordFilter }
 	switchToSearchListMsg struct{ query string }
+	switchToSavedViewMsg  struct{ view data.View }
+	switchToActivityMsg   struct{}
+	switchToStatsMsg      struct{}
+	switchToProgressMsg   struct{ uuid, title string }
 
 	showSearchMsg        struct{ scope data.RecordType }
 	showSessionCreateMsg struct {
 		parents data.RecordParents
 	}
+	showSessionLogMsg struct {
+		parents data.RecordParents
+	}
 )
 
 var (
@@ -62,6 +71,10 @@ var (
 	switchToActionsCmd       = func() tea.Msg { return switchToActionsMsg{} }
 	switchToSessionsCmd      = func() tea.Msg { return switchToSessionsMsg{} }
 	switchToMenuCmd          = func() tea.Msg { return switchToMenuMsg{} }
+	switchToHomeCmd          = func() tea.Msg { return switchToHomeMsg{} }
+	switchToDashboardCmd     = func() tea.Msg { return switchToDashboardMsg{} }
+	switchToActivityCmd      = func() tea.Msg { return switchToActivityMsg{} }
+	switchToStatsCmd         = func() tea.Msg { return switchToStatsMsg{} }
 	switchToSigninCmd        = func() tea.Msg { return switchToSigninMsg{} }
 	switchToSignupCmd        = func() tea.Msg { return switchToSignupMsg{} }
 	switchToResetPasswordCmd = func() tea.Msg { return switchToResetPasswordMsg{} }
@@ -69,6 +82,18 @@ var (
 	switchToHelperListCmd    = func() tea.Msg { return switchToHelperListMsg{} }
 )
 
+func switchToSavedViewCmd(view data.View) tea.Cmd {
+	return func() tea.Msg {
+		return switchToSavedViewMsg{view: view}
+	}
+}
+
+func switchToProgressCmd(record yatijappRecord) tea.Cmd {
+	return func() tea.Msg {
+		return switchToProgressMsg{uuid: record.GetUUID(), title: record.GetTitle()}
+	}
+}
+
 func switchToPreviousCmd(model tea.Model) tea.Cmd {
 	return func() tea.Msg {
 		return switchToPreviousMsg{model: model}
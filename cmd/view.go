@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
@@ -14,6 +16,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/liuminhaw/yatijapp-tui/internal/authclient"
 	"github.com/liuminhaw/yatijapp-tui/internal/data"
+	"github.com/liuminhaw/yatijapp-tui/internal/datetime"
 	"github.com/liuminhaw/yatijapp-tui/internal/model"
 	"github.com/liuminhaw/yatijapp-tui/internal/style"
 	"github.com/liuminhaw/yatijapp-tui/pkg/strview"
@@ -21,7 +24,7 @@ import (
 
 type viewHooks struct {
 	load   func(serverURL, uuid, msg string, client *authclient.AuthClient) tea.Cmd
-	delete func(serverURL, uuid string, client *authclient.AuthClient) tea.Cmd
+	delete func(serverURL string, record yatijappRecord, client *authclient.AuthClient) tea.Cmd
 }
 
 type viewPage struct {
@@ -36,6 +39,21 @@ type viewPage struct {
 	viewport viewport.Model
 	fullView bool
 
+	// links are the resolved wiki links of the note and the backlinks, by
+	// their raw text. backlinks stays nil until searched for with b.
+	// linkOrder lists the links as they appear in the rendered content,
+	// with linkLines their lines.
+	links            map[string]noteLink
+	backlinks        []noteLink
+	findingBacklinks bool
+	linkOrder        []noteLink
+	linkLines        []int
+	linkCursor       int    // selected link, -1 for none
+	rendered         string // rendered content with the link markers
+
+	search  contentSearch
+	yanking bool // waiting for the key following the y prefix
+
 	width  int
 	height int
 
@@ -60,14 +78,16 @@ func newViewPage(
 	vp.Style = style.BorderStyle["focused"]
 
 	return viewPage{
-		cfg:      cfg,
-		uuid:     uuid,
-		viewport: vp,
-		width:    termSize.Width,
-		height:   termSize.Height,
-		spinner:  spinner.New(spinner.WithSpinner(spinner.Line)),
-		loading:  true,
-		prev:     prev,
+		cfg:        cfg,
+		uuid:       uuid,
+		viewport:   vp,
+		linkCursor: -1,
+		search:     newContentSearch(),
+		width:      termSize.Width,
+		height:     termSize.Height,
+		spinner:    spinner.New(spinner.WithSpinner(spinner.Line)),
+		loading:    true,
+		prev:       prev,
 	}
 }
 
@@ -121,6 +141,9 @@ func (v viewPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var popupModel tea.Model
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if v.search.typing || v.yanking {
+			break
+		}
 		switch msg.String() {
 		case "?":
 			v.clearMsg()
@@ -147,7 +170,29 @@ func (v viewPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			v.viewport.Style = style.BorderStyle["focused"]
 		}
+		if v.search.typing {
+			return v, v.search.update(msg, &v.viewport)
+		}
+		if v.yanking {
+			v.yanking = false
+			if v.record == nil {
+				return v, nil
+			}
+			return v, yankRecord(msg.String(), v.record, v.viewportContent())
+		}
 		switch msg.String() {
+		case "y":
+			v.clearMsg()
+			v.yanking = true
+			return v, nil
+		case "/":
+			return v, v.search.open()
+		case "n", "N":
+			v.search.next(&v.viewport, msg.String() == "N")
+			return v, nil
+		case "esc":
+			v.search.clear(&v.viewport)
+			return v, nil
 		case "ctrl+f":
 			v.fullView = !v.fullView
 			if err := v.viewportDisplay(); err != nil {
@@ -171,6 +216,57 @@ func (v viewPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return v, switchToPreviousCmd(v.prevPage())
 		case "e":
 			return v, switchToEditCmd(v.recordType, v.record)
+		case "p":
+			if v.recordType == data.RecordTypeTarget && v.record != nil {
+				return v, switchToProgressCmd(v.record)
+			}
+		case "b":
+			if v.record == nil || v.findingBacklinks {
+				return v, nil
+			}
+			v.findingBacklinks = true
+			v.msg = "Searching backlinks..."
+			return v, findBacklinks(v.cfg.apiEndpoint, v.record, v.cfg.authClient)
+		case "tab", "shift+tab":
+			if len(v.linkOrder) == 0 {
+				return v, nil
+			}
+			if msg.String() == "tab" {
+				v.linkCursor = (v.linkCursor + 1) % len(v.linkOrder)
+			} else {
+				v.linkCursor = (max(v.linkCursor, 0) - 1 + len(v.linkOrder)) % len(v.linkOrder)
+			}
+			v.styleViewportLinks()
+			v.scrollToLink()
+			return v, nil
+		case "enter":
+			if v.linkCursor < 0 {
+				return v, nil
+			}
+			link := v.linkOrder[v.linkCursor]
+			if !link.resolved {
+				v.msg = "Link not found: " + link.raw
+				return v, nil
+			}
+			return v, switchToViewCmd(link.recordType, link.uuid)
+		case "H":
+			if v.record == nil {
+				return v, nil
+			}
+			revisions, err := v.cfg.noteHistory.Revisions(v.uuid)
+			if err != nil {
+				return v, internalErrorCmd("failed to load note history", err)
+			}
+			done := v
+			done.popupModels = nil
+			done.popup = ""
+			record := v.record
+			popupModel = newNoteHistoryPopup(revisions, func(note string) tea.Cmd {
+				return restoreNote(v.cfg, record, note, done, done)
+			})
+			v.popupModels = append(v.popupModels, popupModel)
+			v.popup = v.popupModels[len(v.popupModels)-1].View()
+			return v, nil
 		case "d":
 			if v.record == nil {
 				panic("view page item is nil in delete")
@@ -193,7 +289,7 @@ func (v viewPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					prompts = []string{"Proceed to delete session \"" + v.record.GetTitle() + "\"?"}
 				}
 			}
-			deleteCmd := v.hooks.delete(v.cfg.apiEndpoint, v.uuid, v.cfg.authClient)
+			deleteCmd := v.hooks.delete(v.cfg.apiEndpoint, v.record, v.cfg.authClient)
 			popupModel = model.NewAlert(
 				"Confirm Deletion", "confirmation", prompts, warnings, 60,
 				map[string]tea.Cmd{"confirm": deleteCmd, "cancel": cancelPopupCmd},
@@ -221,8 +317,47 @@ func (v viewPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		v.msg = msg.msg
 		v.loading = false
+		cmd = resolveNoteLinks(v.cfg.apiEndpoint, v.record, v.links, v.cfg.authClient)
+	case noteLinksResolvedMsg:
+		if msg.uuid != v.uuid || v.record == nil {
+			break
+		}
+		if msg.err != nil {
+			v.cfg.logger.Error(msg.err.Error(), slog.String("action", "resolve note links"))
+		}
+		if v.links == nil {
+			v.links = make(map[string]noteLink)
+		}
+		maps.Copy(v.links, msg.links)
+		if err := v.renderViewport(); err != nil {
+			return v, internalErrorCmd("failed to render view page", err)
+		}
+	case backlinksFoundMsg:
+		if msg.uuid != v.uuid || v.record == nil {
+			break
+		}
+		v.findingBacklinks = false
+		if msg.err != nil {
+			v.cfg.logger.Error(msg.err.Error(), slog.String("action", "find backlinks"))
+			v.msg = "Backlinks search failed: " + apiErrorText(msg.err)
+			break
+		}
+		v.backlinks = msg.backlinks
+		if v.links == nil {
+			v.links = make(map[string]noteLink)
+		}
+		for _, link := range msg.backlinks {
+			v.links[link.raw] = link
+		}
+		v.msg = fmt.Sprintf("%d backlinks found", len(msg.backlinks))
+		if err := v.renderViewport(); err != nil {
+			return v, internalErrorCmd("failed to render view page", err)
+		}
 	case recordDeletedMsg:
 		return v, switchToPreviousCmd(v.prev)
+	case yankedMsg:
+		v.msg = yankMessage(msg)
+		return v, nil
 	case internalErrorMsg:
 		v.error = errors.New(msg.msg)
 		v.loading = false
@@ -280,7 +415,11 @@ func (v viewPage) View() string {
 	}
 
 	var title string
-	if v.msg == "" {
+	if v.msg == "" && v.search.active() {
+		title = style.TitleBarView(
+			[]string{string(v.recordType) + " Details", v.search.counter()}, viewWidth, false,
+		)
+	} else if v.msg == "" {
 		title = style.TitleBarView([]string{string(v.recordType) + " Details"}, viewWidth, false)
 	} else {
 		title = style.TitleBarView([]string{v.msg}, viewWidth, true)
@@ -301,9 +440,20 @@ func (v viewPage) View() string {
 		{Key: "↑/↓", Action: "scroll"},
 		{Key: "e", Action: "edit"},
 		{Key: "d", Action: "delete"},
+		{Key: "/", Action: "search"},
 		{Key: "q", Action: "quit"},
 		{Key: "?", Action: "modes"},
 	}, viewWidth)
+	if v.search.typing {
+		helperView = v.search.inputView(viewWidth)
+	}
+	if v.yanking {
+		var items []style.HelperContent
+		if v.recordType == data.RecordTypeSession {
+			items = append(items, style.HelperContent{Key: "s", Action: "summary"})
+		}
+		helperView = yankHelper(items, viewWidth)
+	}
 
 	container := lipgloss.JoinVertical(
 		lipgloss.Center,
@@ -322,60 +472,68 @@ func (v viewPage) View() string {
 }
 
 func (v viewPage) viewportContent() string {
+	return recordMarkdown(v.record) + backlinksMarkdown(v.backlinks)
+}
+
+// recordMarkdown returns the markdown document describing the given record,
+// as shown in the view page and the list page detail pane.
+func recordMarkdown(record yatijappRecord) string {
 	var content strings.Builder
 
-	content.WriteString("# " + v.record.GetTitle() + "\n")
-	description := v.record.GetDescription()
+	recordType := record.GetActualType()
+	content.WriteString("# " + record.GetTitle() + "\n")
+	description := record.GetDescription()
 	if description != "" {
 		content.WriteString(description + "\n\n")
 	} else {
 		content.WriteString("\n")
 	}
 
-	switch v.recordType {
+	switch recordType {
 	case data.RecordTypeAction:
 		content.WriteString("## Upstream\n")
 		content.WriteString(
-			"- **Target:** " + v.record.GetParentsTitle()[data.RecordTypeTarget] + "\n\n",
+			"- **Target:** " + record.GetParentsTitle()[data.RecordTypeTarget] + "\n\n",
 		)
 	case data.RecordTypeSession:
 		content.WriteString("## Upstream\n")
 		content.WriteString(
-			"- **Target:** " + v.record.GetParentsTitle()[data.RecordTypeTarget] + "\n" +
-				"- **Action:** " + v.record.GetParentsTitle()[data.RecordTypeAction] + "\n\n",
+			"- **Target:** " + record.GetParentsTitle()[data.RecordTypeTarget] + "\n" +
+				"- **Action:** " + record.GetParentsTitle()[data.RecordTypeAction] + "\n\n",
 		)
 	}
 
 	content.WriteString("## Status\n")
-	content.WriteString(strings.ToUpper(v.record.GetStatus()) + "\n\n")
+	content.WriteString(strings.ToUpper(record.GetStatus()) + "\n\n")
 
-	due, valid := v.record.GetDueDate()
+	f := datetime.Display()
+	due, valid := record.GetDueDate()
 	content.WriteString("## Timestamp\n")
-	if v.recordType != data.RecordTypeSession {
+	if recordType != data.RecordTypeSession {
 		if valid {
-			content.WriteString("- **Due Date:**: " + due.Format("2006-01-02") + "\n")
+			content.WriteString("- **Due Date:**: " + f.Due(due) + "\n")
 		} else {
 			content.WriteString("- **Due Date:** --\n")
 		}
 		content.WriteString(
-			"- **Created At:** " + v.record.GetCreatedAt().Format("2006-01-02 15:04:05") + "\n",
+			"- **Created At:** " + f.Detailed(record.GetCreatedAt()) + "\n",
 		)
 		content.WriteString(
-			"- **Updated At:** " + v.record.GetUpdatedAt().Format("2006-01-02 15:04:05") + "\n\n",
+			"- **Updated At:** " + f.Detailed(record.GetUpdatedAt()) + "\n\n",
 		)
 		content.WriteString(
-			"- **Last Active:** " + v.record.GetUpdatedAt().Format("2006-01-02 15:04:05") + "\n\n",
+			"- **Last Active:** " + f.Detailed(record.GetLastActive()) + "\n\n",
 		)
 	}
 
-	if v.recordType == data.RecordTypeSession {
-		session := v.record.(data.Session)
+	if recordType == data.RecordTypeSession {
+		session := record.(data.Session)
 		content.WriteString(
-			"- **Starts At:**  " + session.StartsAt.Format("2006-01-02 15:04:05") + "\n",
+			"- **Starts At:**  " + f.Detailed(session.StartsAt) + "\n",
 		)
 		if session.EndsAt.Valid {
 			content.WriteString(
-				"- **Ends At:**    " + session.EndsAt.Time.Format("2006-01-02 15:04:05") + "\n",
+				"- **Ends At:**    " + f.Detailed(session.EndsAt.Time) + "\n",
 			)
 			content.WriteString(
 				"- **Duration:**   " + session.EndsAt.Time.Sub(session.StartsAt).
@@ -389,7 +547,7 @@ func (v viewPage) viewportContent() string {
 	}
 
 	content.WriteString("## Notes\n---\n")
-	note := v.record.GetNote()
+	note := record.GetNote()
 	if note == "" {
 		content.WriteString("(Empty Note)")
 	} else {
@@ -400,8 +558,46 @@ func (v viewPage) viewportContent() string {
 }
 
 func (v *viewPage) renderViewport() error {
+	content, links := markLinks(v.viewportContent(), v.links)
+	str, err := renderMarkdown(content, v.viewport)
+	if err != nil {
+		return err
+	}
+	v.rendered = str
+	v.linkOrder = links
+	if v.linkCursor >= len(links) {
+		v.linkCursor = -1
+	}
+	v.styleViewportLinks()
+
+	return nil
+}
+
+// styleViewportLinks sets the rendered content with its links styled.
+func (v *viewPage) styleViewportLinks() {
+	content, lines := styleLinks(v.rendered, v.linkOrder, v.linkCursor)
+	v.linkLines = lines
+	v.search.setContent(&v.viewport, content)
+}
+
+// scrollToLink scrolls the selected link into view.
+func (v *viewPage) scrollToLink() {
+	if v.linkCursor < 0 || v.linkCursor >= len(v.linkLines) {
+		return
+	}
+
+	line := v.linkLines[v.linkCursor]
+	height := v.viewport.Height - v.viewport.Style.GetVerticalFrameSize()
+	if line < v.viewport.YOffset || line >= v.viewport.YOffset+height {
+		v.viewport.SetYOffset(line - height/2)
+	}
+}
+
+// renderMarkdown renders markdown content with glamour, word wrapped to fit
+// inside the given viewport.
+func renderMarkdown(content string, vp viewport.Model) (string, error) {
 	const glamourGutter = 2
-	glamourRenderWidth := v.viewport.Width - glamourGutter - v.viewport.Style.GetHorizontalFrameSize()
+	glamourRenderWidth := vp.Width - glamourGutter - vp.Style.GetHorizontalFrameSize()
 
 	renderer, err := glamour.NewTermRenderer(
 		glamour.WithAutoStyle(),
@@ -409,16 +605,10 @@ func (v *viewPage) renderViewport() error {
 		glamour.WithEmoji(),
 	)
 	if err != nil {
-		return err
-	}
-
-	str, err := renderer.Render(v.viewportContent())
-	if err != nil {
-		return err
+		return "", err
 	}
-	v.viewport.SetContent(str)
 
-	return nil
+	return renderer.Render(content)
 }
 
 func (v *viewPage) clearMsg() {
@@ -429,9 +619,21 @@ func (v *viewPage) helperPopup(width int) {
 	items := map[string]string{
 		"<C-f>": "Toggle full screen",
 		"<C-e>": "Open in editor",
+		"H":     "Note history",
+		"b":     "Find backlinks",
+		"Tab":   "Next note link",
+		"Enter": "Open note link",
+		"/":     "Search content",
+		"n/N":   "Next/previous match",
+		"Esc":   "Clear search",
+		"y":     "Yank (copy)",
 		"?":     "Toggle mode helper",
 	}
-	order := []string{"<C-f>", "<C-e>", "?"}
+	order := []string{"<C-f>", "<C-e>", "H", "b", "Tab", "Enter", "/", "n/N", "Esc", "y", "?"}
+	if v.recordType == data.RecordTypeTarget {
+		items["p"] = "Progress"
+		order = slices.Insert(order, 3, "p")
+	}
 
 	v.popup = style.FullHelpView([]style.FullHelpContent{
 		{
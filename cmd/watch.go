@@ -0,0 +1,196 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/liuminhaw/yatijapp-tui/internal/authclient"
+	"github.com/liuminhaw/yatijapp-tui/internal/data"
+	"github.com/liuminhaw/yatijapp-tui/internal/datetime"
+	"github.com/liuminhaw/yatijapp-tui/internal/model"
+)
+
+const (
+	sessionWatchInterval = time.Minute
+	activitySaveInterval = time.Minute
+	sessionWatchPageSize = 50
+	sessionWatchMaxPages = 10
+)
+
+type sessionWatchConfig struct {
+	runawayAfter time.Duration // 0 disables runaway detection
+	idleAfter    time.Duration // 0 disables idle detection
+	stateFile    string        // keeps the last active time across runs
+}
+
+type (
+	// sessionWatchTickMsg and openSessionsFoundMsg carry the seq of the
+	// watch they belong to, dropped once the user signs out.
+	sessionWatchTickMsg struct {
+		seq int
+	}
+	openSessionsFoundMsg struct {
+		seq      int
+		sessions []data.Session
+	}
+	sessionWatchErrMsg struct {
+		err error
+	}
+	sessionWatchEndMsg struct {
+		session data.Session
+		endsAt  time.Time
+	}
+	sessionWatchKeepMsg struct {
+		uuid string
+	}
+)
+
+type activityState struct {
+	LastActive time.Time `json:"last_active"`
+}
+
+// loadLastActive returns the last time input was seen, as saved by the
+// previous run. A zero time is returned if nothing was saved yet.
+func loadLastActive(path string) (time.Time, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return time.Time{}, nil
+	} else if err != nil {
+		return time.Time{}, err
+	}
+
+	var state activityState
+	if err := json.Unmarshal(content, &state); err != nil {
+		return time.Time{}, err
+	}
+
+	return state.LastActive, nil
+}
+
+func saveLastActive(path string, t time.Time) error {
+	content, err := json.Marshal(activityState{LastActive: t})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, content, 0o600)
+}
+
+func sessionWatchTick(seq int) tea.Cmd {
+	return tea.Tick(sessionWatchInterval, func(time.Time) tea.Msg {
+		return sessionWatchTickMsg{seq: seq}
+	})
+}
+
+// loadOpenSessions pages through the sessions which have not been ended yet.
+func loadOpenSessions(seq int, serverURL string, client *authclient.AuthClient) tea.Cmd {
+	return func() tea.Msg {
+		var sessions []data.Session
+		for page := 1; page <= sessionWatchMaxPages; page++ {
+			resp, err := data.ListSessions(data.ListRequestInfo{
+				ServerURL: serverURL,
+				QueryStrings: map[string]string{
+					"status":    "in progress",
+					"page":      strconv.Itoa(page),
+					"page_size": strconv.Itoa(sessionWatchPageSize),
+				},
+			}, client)
+			if err != nil {
+				return sessionWatchErrMsg{err: err}
+			}
+
+			for _, session := range resp.Sessions {
+				if !session.EndsAt.Valid {
+					sessions = append(sessions, session)
+				}
+			}
+			if len(resp.Sessions) < sessionWatchPageSize || page >= resp.Metadata.LastPage {
+				break
+			}
+		}
+
+		return openSessionsFoundMsg{seq: seq, sessions: sessions}
+	}
+}
+
+// forgottenSession checks whether the session looks forgotten, either running
+// for longer than the runaway threshold or idle for longer than the idle
+// threshold. It returns the time the session should have ended at along with
+// the reason.
+func forgottenSession(
+	cfg sessionWatchConfig,
+	session data.Session,
+	lastActive, now time.Time,
+) (time.Time, string, bool) {
+	endsAt := now
+	if lastActive.After(session.StartsAt) && lastActive.Before(now) {
+		endsAt = lastActive
+	}
+
+	switch {
+	case cfg.idleAfter > 0 && lastActive.After(session.StartsAt) &&
+		now.Sub(lastActive) >= cfg.idleAfter:
+		return endsAt, fmt.Sprintf(
+			"No input since %s", datetime.Display().DateTime(lastActive),
+		), true
+	case cfg.runawayAfter > 0 && now.Sub(session.StartsAt) >= cfg.runawayAfter:
+		return endsAt, fmt.Sprintf(
+			"Running for %s", shortDuration(now.Sub(session.StartsAt).Round(time.Minute)),
+		), true
+	}
+
+	return time.Time{}, "", false
+}
+
+func forgottenSessionPrompt(session data.Session, endsAt time.Time, reason string) tea.Model {
+	return model.NewAlert(
+		"Forgotten session?",
+		"confirmation",
+		[]string{
+			reason + ".",
+			"End session of \"" + session.ActionTitle + "\"",
+			"at " + datetime.Display().DateTime(endsAt) + "?",
+		},
+		[]string{""},
+		60,
+		map[string]tea.Cmd{
+			"confirm": func() tea.Msg { return sessionWatchEndMsg{session: session, endsAt: endsAt} },
+			"cancel":  func() tea.Msg { return sessionWatchKeepMsg{uuid: session.UUID} },
+		},
+	)
+}
+
+func endForgottenSession(
+	serverURL string,
+	msg sessionWatchEndMsg,
+	redirect tea.Model,
+	client *authclient.AuthClient,
+) tea.Cmd {
+	d := recordRequestData{
+		uuid:       msg.session.UUID,
+		actionUUID: msg.session.ActionUUID,
+		endsAt:     sql.NullTime{Valid: true, Time: msg.endsAt},
+		before:     msg.session,
+	}
+
+	return updateSession(
+		serverURL,
+		"Session ended at "+datetime.Display().Clock(msg.endsAt),
+		d,
+		Water{},
+		redirect,
+		client,
+	)
+}
@@ -0,0 +1,372 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/liuminhaw/yatijapp-tui/colors"
+	"github.com/liuminhaw/yatijapp-tui/internal/authclient"
+	"github.com/liuminhaw/yatijapp-tui/internal/data"
+	"github.com/liuminhaw/yatijapp-tui/pkg/wikilink"
+)
+
+const (
+	// linkStart and linkEnd surround the links in the markdown given to
+	// glamour, they are replaced with the link styles once rendered.
+	linkStart = "\uE000"
+	linkEnd   = "\uE001"
+
+	backlinksPageSize = 20
+	// linkWorkers bounds the requests resolving links at the same time.
+	linkWorkers = 4
+)
+
+// noteLink is a wiki link of a note, along with the record it resolved to.
+type noteLink struct {
+	raw        string
+	recordType data.RecordType
+	uuid       string
+	title      string
+	resolved   bool
+}
+
+type noteLinksResolvedMsg struct {
+	uuid  string
+	links map[string]noteLink // by raw link text
+	err   error
+}
+
+type backlinksFoundMsg struct {
+	uuid      string
+	backlinks []noteLink
+	err       error
+}
+
+// forEachLimited calls fn for each index below n, with at most linkWorkers
+// calls running at the same time.
+func forEachLimited(n int, fn func(i int)) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, linkWorkers)
+	for i := range n {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(i)
+		}()
+	}
+	wg.Wait()
+}
+
+// fetchRecord gets the record of the given type.
+func fetchRecord(
+	serverURL, uuid string,
+	rt data.RecordType,
+	client *authclient.AuthClient,
+) (yatijappRecord, error) {
+	switch rt {
+	case data.RecordTypeTarget:
+		return data.GetTarget(serverURL, uuid, client)
+	case data.RecordTypeAction:
+		return data.GetAction(serverURL, uuid, client)
+	case data.RecordTypeSession:
+		return data.GetSession(serverURL, uuid, client)
+	default:
+		panic("unsupported record type in fetchRecord")
+	}
+}
+
+func isNotFound(err error) bool {
+	var ue data.UnauthorizedApiDataErr
+	return errors.As(err, &ue) && ue.Status == http.StatusNotFound
+}
+
+func linkRecordType(kind string) data.RecordType {
+	if kind == "" {
+		return ""
+	}
+	return data.RecordType(strings.ToUpper(kind[:1]) + kind[1:])
+}
+
+// resolveLink finds the record a link refers to. Untyped uuid links are
+// looked up as every record type at once.
+func resolveLink(
+	serverURL string,
+	link wikilink.Link,
+	client *authclient.AuthClient,
+) (noteLink, error) {
+	resolved := noteLink{raw: link.Raw}
+
+	if link.ByUUID() {
+		types := []data.RecordType{linkRecordType(link.Kind)}
+		if link.Kind == "" {
+			types = []data.RecordType{
+				data.RecordTypeTarget, data.RecordTypeAction, data.RecordTypeSession,
+			}
+		}
+
+		records := make([]yatijappRecord, len(types))
+		errs := make([]error, len(types))
+		var wg sync.WaitGroup
+		for i, rt := range types {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				records[i], errs[i] = fetchRecord(serverURL, link.Ref, rt, client)
+			}()
+		}
+		wg.Wait()
+
+		for i, rt := range types {
+			if isNotFound(errs[i]) {
+				continue
+			} else if errs[i] != nil {
+				return resolved, errs[i]
+			}
+			resolved.recordType = rt
+			resolved.uuid = records[i].GetUUID()
+			resolved.title = records[i].GetTitle()
+			resolved.resolved = true
+			return resolved, nil
+		}
+		return resolved, nil
+	}
+
+	resp, err := data.ListRecords(data.ListRequestInfo{
+		ServerURL:    serverURL,
+		QueryStrings: map[string]string{"search": link.Ref},
+	}, client)
+	if err != nil {
+		return resolved, err
+	}
+	for _, record := range resp.Records {
+		if record.GetActualType() == linkRecordType(link.Kind) &&
+			strings.EqualFold(record.Title, link.Ref) {
+			resolved.recordType = record.GetActualType()
+			resolved.uuid = record.UUID
+			resolved.title = record.Title
+			resolved.resolved = true
+			break
+		}
+	}
+
+	return resolved, nil
+}
+
+// linksTo reports whether the note has a link to the record.
+func linksTo(note string, record yatijappRecord) bool {
+	kind := strings.ToLower(string(record.GetActualType()))
+	for _, link := range wikilink.Parse(note) {
+		if link.Kind != "" && link.Kind != kind {
+			continue
+		}
+		if strings.EqualFold(link.Ref, record.GetUUID()) {
+			return true
+		}
+		if link.Kind != "" && strings.EqualFold(link.Ref, record.GetTitle()) {
+			return true
+		}
+	}
+	return false
+}
+
+// findBacklinks searches the records mentioning the record by uuid or title,
+// and keeps the ones with a note linking to it.
+func findBacklinks(
+	serverURL string,
+	record yatijappRecord,
+	client *authclient.AuthClient,
+) tea.Cmd {
+	return func() tea.Msg {
+		msg := backlinksFoundMsg{uuid: record.GetUUID()}
+
+		queries := []string{record.GetUUID()}
+		if record.GetActualType() != data.RecordTypeSession {
+			queries = append(queries, record.GetTitle())
+		}
+
+		var candidates []data.Record
+		seen := map[string]bool{record.GetUUID(): true}
+		for _, query := range queries {
+			resp, err := data.ListRecords(data.ListRequestInfo{
+				ServerURL: serverURL,
+				QueryStrings: map[string]string{
+					"search":    query,
+					"page_size": strconv.Itoa(backlinksPageSize),
+				},
+			}, client)
+			if err != nil {
+				msg.err = err
+				return msg
+			}
+
+			for _, candidate := range resp.Records {
+				if !seen[candidate.UUID] && candidate.HasNotes {
+					seen[candidate.UUID] = true
+					candidates = append(candidates, candidate)
+				}
+			}
+		}
+
+		found := make([]*noteLink, len(candidates))
+		errs := make([]error, len(candidates))
+		forEachLimited(len(candidates), func(i int) {
+			full, err := fetchRecord(serverURL, candidates[i].UUID, candidates[i].GetActualType(), client)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			if linksTo(full.GetNote(), record) {
+				found[i] = &noteLink{
+					raw:        "[[" + strings.ToLower(string(full.GetActualType())) + ":" + full.GetUUID() + "]]",
+					recordType: full.GetActualType(),
+					uuid:       full.GetUUID(),
+					title:      full.GetTitle(),
+					resolved:   true,
+				}
+			}
+		})
+
+		msg.backlinks = []noteLink{}
+		for i, link := range found {
+			if errs[i] != nil && msg.err == nil {
+				msg.err = errs[i]
+			}
+			if link != nil {
+				msg.backlinks = append(msg.backlinks, *link)
+			}
+		}
+		return msg
+	}
+}
+
+// resolveNoteLinks resolves the links of the record note which are not in
+// known yet.
+func resolveNoteLinks(
+	serverURL string,
+	record yatijappRecord,
+	known map[string]noteLink,
+	client *authclient.AuthClient,
+) tea.Cmd {
+	var links []wikilink.Link
+	seen := make(map[string]bool)
+	for _, link := range wikilink.Parse(record.GetNote()) {
+		if _, ok := known[link.Raw]; !ok && !seen[link.Raw] {
+			seen[link.Raw] = true
+			links = append(links, link)
+		}
+	}
+	if len(links) == 0 {
+		return nil
+	}
+
+	return func() tea.Msg {
+		resolved := make([]noteLink, len(links))
+		errs := make([]error, len(links))
+		forEachLimited(len(links), func(i int) {
+			resolved[i], errs[i] = resolveLink(serverURL, links[i], client)
+		})
+
+		msg := noteLinksResolvedMsg{
+			uuid:  record.GetUUID(),
+			links: make(map[string]noteLink),
+		}
+		for i, link := range resolved {
+			if errs[i] != nil {
+				// left out to be resolved again on the next load
+				msg.err = errs[i]
+				continue
+			}
+			msg.links[link.raw] = link
+		}
+		return msg
+	}
+}
+
+// backlinksMarkdown lists the backlinks as links, to be rendered like the
+// note links. Nothing is listed until the backlinks are searched for.
+func backlinksMarkdown(backlinks []noteLink) string {
+	if backlinks == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("\n\n## Backlinks\n")
+	if len(backlinks) == 0 {
+		b.WriteString("No notes link here.\n")
+	}
+	for _, link := range backlinks {
+		b.WriteString("- " + link.raw + "\n")
+	}
+	return b.String()
+}
+
+// markLinks replaces the links of the markdown with their labels between
+// the link markers, and returns the links in order.
+func markLinks(content string, links map[string]noteLink) (string, []noteLink) {
+	var order []noteLink
+	marked := wikilink.Replace(content, func(link wikilink.Link) string {
+		resolved, ok := links[link.Raw]
+		if !ok {
+			resolved = noteLink{raw: link.Raw}
+		}
+		order = append(order, resolved)
+
+		label := link.Ref
+		if resolved.resolved {
+			label = resolved.title
+		}
+		return linkStart + label + linkEnd
+	})
+
+	return marked, order
+}
+
+// styleLinks replaces the link markers of the rendered content with the link
+// styles, the selected link is reversed. It returns the line of each link.
+func styleLinks(rendered string, links []noteLink, selected int) (string, []int) {
+	resolvedStyle := lipgloss.NewStyle().Foreground(colors.Info).Underline(true)
+	unresolvedStyle := lipgloss.NewStyle().Foreground(colors.Danger).Strikethrough(true)
+
+	var b strings.Builder
+	var lines []int
+	line := 0
+	i := 0
+	for _, r := range rendered {
+		switch string(r) {
+		case linkStart:
+			st := unresolvedStyle
+			if i < len(links) && links[i].resolved {
+				st = resolvedStyle
+			}
+			if i == selected {
+				st = st.Reverse(true)
+			}
+			b.WriteString(styleSequence(st))
+			lines = append(lines, line)
+			i++
+		case linkEnd:
+			b.WriteString("\x1b[0m")
+		default:
+			if r == '\n' {
+				line++
+			}
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String(), lines
+}
+
+// styleSequence returns the escape sequence the style starts with.
+func styleSequence(st lipgloss.Style) string {
+	const probe = "\x00"
+	rendered := st.Render(probe)
+	return rendered[:strings.Index(rendered, probe)]
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/liuminhaw/yatijapp-tui/internal/data"
+	"github.com/liuminhaw/yatijapp-tui/internal/datetime"
+	"github.com/liuminhaw/yatijapp-tui/internal/style"
+)
+
+type yankedMsg struct {
+	label string
+	err   error
+}
+
+// yankCmd copies the text to the clipboard with an OSC 52 sequence, which
+// terminals honour over SSH as well. Outside of SSH sessions the text is also
+// copied to the local clipboard when one is available.
+func yankCmd(label, text string) tea.Cmd {
+	return func() tea.Msg {
+		seq := osc52.New(text)
+		switch {
+		case os.Getenv("TMUX") != "":
+			seq = seq.Tmux()
+		case strings.HasPrefix(os.Getenv("TERM"), "screen"):
+			seq = seq.Screen()
+		}
+		err := writeToTerminal(seq.String())
+
+		if !overSSH() && !clipboard.Unsupported {
+			if err := clipboard.WriteAll(text); err != nil {
+				return yankedMsg{label: label, err: err}
+			}
+			return yankedMsg{label: label}
+		}
+
+		return yankedMsg{label: label, err: err}
+	}
+}
+
+// writeToTerminal writes the sequence to the terminal the program runs in,
+// whatever its output is redirected to. The sequence goes in a single write
+// so that it lands between the frames of the renderer.
+func writeToTerminal(seq string) error {
+	tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("no terminal to copy through: %w", err)
+	}
+	defer tty.Close()
+
+	_, err = tty.WriteString(seq)
+	return err
+}
+
+func overSSH() bool {
+	return os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != ""
+}
+
+// yankMessage describes the outcome of a yank for the title bar.
+func yankMessage(msg yankedMsg) string {
+	if msg.err != nil {
+		return "Failed to copy " + msg.label + ": " + msg.err.Error()
+	}
+	return "Copied " + msg.label + " to clipboard"
+}
+
+// yankHelper lists the yank targets following the y prefix.
+func yankHelper(items []style.HelperContent, width int) string {
+	helper := []style.HelperContent{
+		{Key: "u", Action: "uuid"},
+		{Key: "t", Action: "title"},
+		{Key: "m", Action: "markdown"},
+	}
+	helper = append(helper, items...)
+	helper = append(helper, style.HelperContent{Key: "Esc", Action: "cancel"})
+
+	return style.HelperView(helper, width)
+}
+
+// yankRecord returns the command copying the part of the record the key
+// stands for, or nil for keys with nothing to copy.
+func yankRecord(key string, record yatijappRecord, markdown string) tea.Cmd {
+	if record == nil {
+		return nil
+	}
+
+	switch key {
+	case "u":
+		return yankCmd("uuid", record.GetUUID())
+	case "t":
+		return yankCmd("title", record.GetTitle())
+	case "m":
+		return yankCmd("markdown", markdown)
+	case "s":
+		if session, ok := record.(data.Session); ok {
+			return yankCmd("session summary", sessionSummary(session, time.Now()))
+		}
+	}
+
+	return nil
+}
+
+// recordsChecklist returns a markdown checklist of the records, completed
+// records are checked.
+func recordsChecklist(records []yatijappRecord) string {
+	var b strings.Builder
+	for _, record := range records {
+		check := " "
+		if record.GetStatus() == "completed" {
+			check = "x"
+		}
+		title := record.GetTitle()
+		if session, ok := record.(data.Session); ok {
+			title = session.ActionTitle + " " + title
+		}
+		b.WriteString("- [" + check + "] " + title + "\n")
+	}
+	return b.String()
+}
+
+// sessionSummary describes the session on one line, like
+// "Write migration (Release 2.0) · 09:00 → 10:30 · 1h30m · completed".
+func sessionSummary(session data.Session, now time.Time) string {
+	f := datetime.Display()
+
+	end := "now"
+	if session.EndsAt.Valid {
+		end = f.Clock(session.EndsAt.Time)
+	}
+	start, finish := sessionInterval(session.StartsAt, session.EndsAt)
+	if !session.EndsAt.Valid {
+		finish = now
+	}
+
+	return fmt.Sprintf(
+		"%s (%s) · %s %s → %s · %s · %s",
+		session.ActionTitle,
+		session.TargetTitle,
+		f.Date(session.StartsAt),
+		f.Clock(session.StartsAt),
+		end,
+		shortDuration(finish.Sub(start).Round(time.Minute)),
+		session.GetStatus(),
+	)
+}
@@ -0,0 +1,13 @@
+package colors
+
+import "github.com/charmbracelet/lipgloss"
+
+// Heat are the levels of the activity heatmap, from no activity to the
+// busiest days.
+var Heat = []lipgloss.AdaptiveColor{
+	{Light: "#d8cfc3", Dark: "#2A241B"},
+	{Light: "#d9b98a", Dark: "#5A4627"},
+	{Light: "#b98d4f", Dark: "#85693C"},
+	{Light: "#8c5c14", Dark: "#B08A52"},
+	{Light: "#663e00", Dark: "#D6A966"},
+}
@@ -0,0 +1,172 @@
+// Package activity keeps a local journal of the changes made from this
+// client. The journal is an append-only JSON lines file, one entry per line.
+package activity
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Events of the journal besides the record events of the hook package.
+const (
+	SignedIn  = "user.signed_in"
+	SignedOut = "user.signed_out"
+)
+
+// Entry is a change made from this client. Before and After are the record
+// before and after the change, either of them is empty when not known.
+type Entry struct {
+	At         time.Time       `json:"at"`
+	Event      string          `json:"event"`
+	Endpoint   string          `json:"endpoint"`
+	RecordType string          `json:"record_type,omitempty"`
+	UUID       string          `json:"uuid,omitempty"`
+	Title      string          `json:"title,omitempty"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+}
+
+// Log is the journal kept in the file at Path.
+type Log struct {
+	Path string
+}
+
+var mu sync.Mutex
+
+// Append adds the entries at the end of the journal.
+func (l Log) Append(entries ...Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.Path), 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(f)
+	for _, e := range entries {
+		if err := encoder.Encode(e); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	return f.Close()
+}
+
+// Entries returns the entries of the journal matching the filter, most
+// recent first. Lines which cannot be decoded, like a line cut short by a
+// crash, are skipped.
+func (l Log) Entries(filter Filter) ([]Entry, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	f, err := os.Open(l.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		if filter.Match(e) {
+			entries = append(entries, e)
+		}
+	}
+	slices.Reverse(entries)
+
+	return entries, scanner.Err()
+}
+
+// Change is a field of the record changed by an entry.
+type Change struct {
+	Field  string
+	Before string
+	After  string
+}
+
+// ignoredFields change along with any update, they are left out of the
+// changes.
+var ignoredFields = []string{"updated_at", "version", "last_active"}
+
+// Changes returns the fields changed from Before to After, in the order of
+// the field names. There are no changes unless both records are known.
+func (e Entry) Changes() []Change {
+	var before, after map[string]any
+	if json.Unmarshal(e.Before, &before) != nil || json.Unmarshal(e.After, &after) != nil {
+		return nil
+	}
+
+	var fields []string
+	for field := range after {
+		if !slices.Contains(ignoredFields, field) && !reflect.DeepEqual(before[field], after[field]) {
+			fields = append(fields, field)
+		}
+	}
+	slices.Sort(fields)
+
+	changes := make([]Change, len(fields))
+	for i, field := range fields {
+		changes[i] = Change{
+			Field:  field,
+			Before: formatValue(before[field]),
+			After:  formatValue(after[field]),
+		}
+	}
+	return changes
+}
+
+// formatValue formats a field value on a single line. Null times, encoded
+// as objects with a Valid field, show as their time.
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "-"
+	case string:
+		if v == "" {
+			return "-"
+		}
+		return strings.Join(strings.Fields(v), " ")
+	case map[string]any:
+		if valid, ok := v["Valid"].(bool); ok {
+			if !valid {
+				return "-"
+			}
+			for key, value := range v {
+				if key != "Valid" {
+					return formatValue(value)
+				}
+			}
+		}
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
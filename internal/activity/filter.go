@@ -0,0 +1,83 @@
+package activity
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/liuminhaw/yatijapp-tui/internal/datetime"
+)
+
+// Filter narrows down the entries of the journal, its zero value matches all
+// the entries.
+type Filter struct {
+	Since      time.Time // entries on or after the day
+	Until      time.Time // entries on or before the day
+	Event      string    // path pattern, like "session.*"
+	RecordType string
+	Text       []string // words found in the title or uuid
+}
+
+// ParseFilter parses filter terms separated by spaces: since:<date>,
+// until:<date>, event:<pattern> and type:<record type>. Other words are
+// matched against the title and uuid of the entries. Dates are resolved at
+// now like the date fields, "since:yesterday" or "until:-3d".
+func ParseFilter(input string, now time.Time) (Filter, error) {
+	var f Filter
+	for _, word := range strings.Fields(input) {
+		key, value, ok := strings.Cut(word, ":")
+		if !ok || value == "" {
+			f.Text = append(f.Text, strings.ToLower(word))
+			continue
+		}
+
+		switch strings.ToLower(key) {
+		case "since", "until":
+			t, err := datetime.ParseDate(value, now)
+			if err != nil {
+				return Filter{}, fmt.Errorf("invalid %s date %q", key, value)
+			}
+			if strings.ToLower(key) == "since" {
+				f.Since = t
+			} else {
+				f.Until = t
+			}
+		case "event":
+			if _, err := path.Match(value, ""); err != nil {
+				return Filter{}, fmt.Errorf("invalid event pattern %q", value)
+			}
+			f.Event = strings.ToLower(value)
+		case "type":
+			f.RecordType = strings.ToLower(strings.TrimSuffix(value, "s"))
+		default:
+			f.Text = append(f.Text, strings.ToLower(word))
+		}
+	}
+
+	return f, nil
+}
+
+// Match reports whether the entry passes the filter.
+func (f Filter) Match(e Entry) bool {
+	days := datetime.DayRange{From: f.Since, To: f.Until}
+	if !days.Contains(e.At.In(datetime.Now().Location())) {
+		return false
+	}
+	if f.Event != "" {
+		if matched, _ := path.Match(f.Event, e.Event); !matched && !strings.HasPrefix(e.Event, f.Event+".") {
+			return false
+		}
+	}
+	if f.RecordType != "" && e.RecordType != f.RecordType {
+		return false
+	}
+
+	haystack := strings.ToLower(e.Title + " " + e.UUID)
+	for _, word := range f.Text {
+		if !strings.Contains(haystack, word) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,324 @@
+package components
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/liuminhaw/yatijapp-tui/colors"
+	"github.com/liuminhaw/yatijapp-tui/internal/style"
+)
+
+const (
+	DatePickerLayout     = "2006-01-02"
+	DateTimePickerLayout = "2006-01-02 15:04:05"
+)
+
+const (
+	pickerFocusDay = iota
+	pickerFocusHour
+	pickerFocusMinute
+)
+
+type (
+	DatePickerSelectedMsg struct {
+		Value string
+	}
+	DatePickerCanceledMsg struct{}
+)
+
+// DatePicker is a calendar popup for picking a date, and optionally a time of
+// day. Days rejected by ValidateFunc are greyed out and can not be picked.
+type DatePicker struct {
+	cursor   time.Time
+	withTime bool
+	focus    int
+	focused  bool
+
+	err error
+
+	ValidateFunc func(string) error
+}
+
+func NewDatePicker(value time.Time, withTime bool) *DatePicker {
+	if value.IsZero() {
+		value = time.Now()
+	}
+	if !withTime {
+		value = time.Date(value.Year(), value.Month(), value.Day(), 0, 0, 0, 0, value.Location())
+	}
+
+	return &DatePicker{
+		cursor:   value.Truncate(time.Second),
+		withTime: withTime,
+		focused:  true,
+	}
+}
+
+func (d *DatePicker) Blur() {
+	d.focused = false
+}
+
+func (d *DatePicker) Focus() tea.Cmd {
+	d.focused = true
+	return nil
+}
+
+func (d *DatePicker) Focused() bool {
+	return d.focused
+}
+
+func (d *DatePicker) Init() tea.Cmd {
+	return nil
+}
+
+func (d *DatePicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if !d.focused {
+		return d, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return d, nil
+	}
+
+	switch keyMsg.String() {
+	case "esc", "ctrl+[":
+		return d, func() tea.Msg { return DatePickerCanceledMsg{} }
+	case "enter":
+		d.Validate()
+		if d.err != nil {
+			return d, nil
+		}
+		value := d.Value()
+		return d, func() tea.Msg { return DatePickerSelectedMsg{Value: value} }
+	case "pgup":
+		d.cursor = addMonths(d.cursor, -1)
+	case "pgdown":
+		d.cursor = addMonths(d.cursor, 1)
+	case "t":
+		now := time.Now()
+		d.cursor = time.Date(
+			now.Year(), now.Month(), now.Day(),
+			d.cursor.Hour(), d.cursor.Minute(), d.cursor.Second(), 0, d.cursor.Location(),
+		)
+	case "tab":
+		if d.withTime {
+			d.focus = (d.focus + 1) % 3
+		}
+	case "shift+tab":
+		if d.withTime {
+			d.focus = (d.focus + 2) % 3
+		}
+	default:
+		switch d.focus {
+		case pickerFocusDay:
+			d.moveDay(keyMsg.String())
+		case pickerFocusHour:
+			d.spin(keyMsg.String(), time.Hour)
+		case pickerFocusMinute:
+			d.spin(keyMsg.String(), time.Minute)
+		}
+	}
+	d.err = nil
+
+	return d, nil
+}
+
+func (d *DatePicker) moveDay(key string) {
+	switch key {
+	case "left", "h":
+		d.cursor = d.cursor.AddDate(0, 0, -1)
+	case "right", "l":
+		d.cursor = d.cursor.AddDate(0, 0, 1)
+	case "up", "k":
+		d.cursor = d.cursor.AddDate(0, 0, -7)
+	case "down", "j":
+		d.cursor = d.cursor.AddDate(0, 0, 7)
+	}
+}
+
+// spin changes the hour or minute of the cursor, wrapping around within the
+// same day.
+func (d *DatePicker) spin(key string, unit time.Duration) {
+	var step int
+	switch key {
+	case "up", "k", "+":
+		step = 1
+	case "down", "j", "-":
+		step = -1
+	default:
+		return
+	}
+
+	hour, minute := d.cursor.Hour(), d.cursor.Minute()
+	if unit == time.Hour {
+		hour = (hour + step + 24) % 24
+	} else {
+		minute = (minute + step + 60) % 60
+	}
+	d.cursor = time.Date(
+		d.cursor.Year(), d.cursor.Month(), d.cursor.Day(),
+		hour, minute, d.cursor.Second(), 0, d.cursor.Location(),
+	)
+}
+
+func (d *DatePicker) layout() string {
+	if d.withTime {
+		return DateTimePickerLayout
+	}
+	return DatePickerLayout
+}
+
+func (d *DatePicker) allowed(day time.Time) bool {
+	if d.ValidateFunc == nil {
+		return true
+	}
+	return d.ValidateFunc(day.Format(d.layout())) == nil
+}
+
+func (d *DatePicker) View() string {
+	title := style.Document.Secondary.Bold(true).Render(d.cursor.Format("January 2006"))
+
+	var b strings.Builder
+	b.WriteString(style.Document.NormalDim.Render("Mo Tu We Th Fr Sa Su") + "\n")
+
+	first := time.Date(d.cursor.Year(), d.cursor.Month(), 1, 0, 0, 0, 0, d.cursor.Location())
+	offset := (int(first.Weekday()) + 6) % 7 // weeks start on monday
+	b.WriteString(strings.Repeat("   ", offset))
+
+	today := time.Now()
+	for day := first; day.Month() == first.Month(); day = day.AddDate(0, 0, 1) {
+		cell := fmt.Sprintf("%2d", day.Day())
+		dayTime := time.Date(
+			day.Year(), day.Month(), day.Day(),
+			d.cursor.Hour(), d.cursor.Minute(), d.cursor.Second(), 0, day.Location(),
+		)
+
+		cellStyle := style.Document.Normal
+		switch {
+		case day.Day() == d.cursor.Day():
+			cellStyle = lipgloss.NewStyle().Foreground(colors.Bg).Background(colors.Primary).Bold(true)
+			if d.focus != pickerFocusDay {
+				cellStyle = style.Document.Primary.Bold(true).Underline(true)
+			}
+		case !d.allowed(dayTime):
+			cellStyle = lipgloss.NewStyle().Foreground(colors.HelperTextDim).Strikethrough(true)
+		case sameDay(day, today):
+			cellStyle = style.Document.Secondary.Bold(true)
+		}
+		b.WriteString(cellStyle.Render(cell))
+
+		if (offset+day.Day())%7 == 0 {
+			b.WriteString("\n")
+		} else {
+			b.WriteString(" ")
+		}
+	}
+	calendar := lipgloss.NewStyle().Width(20).Render(strings.TrimRight(b.String(), " \n"))
+
+	sections := []string{title, "", calendar}
+	if d.withTime {
+		sections = append(sections, "", d.timeView())
+	}
+	if d.err != nil {
+		sections = append(sections, "", style.ErrorStyle.Render(d.err.Error()))
+	}
+	sections = append(sections, "", d.helperView())
+
+	return style.BorderStyle["highlighted"].
+		Padding(0, 2).
+		Render(lipgloss.JoinVertical(lipgloss.Center, sections...))
+}
+
+func (d *DatePicker) timeView() string {
+	spinner := func(value int, focused bool) string {
+		s := fmt.Sprintf("%02d", value)
+		if focused {
+			return style.Document.Primary.Bold(true).Render("▲" + s + "▼")
+		}
+		return style.Document.Normal.Render(" " + s + " ")
+	}
+
+	return style.Document.NormalDim.Render("Time ") +
+		spinner(d.cursor.Hour(), d.focus == pickerFocusHour) +
+		style.Document.Normal.Render(":") +
+		spinner(d.cursor.Minute(), d.focus == pickerFocusMinute)
+}
+
+func (d *DatePicker) helperView() string {
+	rows := [][]style.HelperContent{
+		{{Key: "←↑↓→", Action: "day"}, {Key: "PgUp/PgDn", Action: "month"}},
+		{{Key: "t", Action: "today"}, {Key: "Enter", Action: "pick"}, {Key: "Esc", Action: "cancel"}},
+	}
+	if d.withTime {
+		rows = append(rows, []style.HelperContent{
+			{Key: "Tab", Action: "day/hour/minute"}, {Key: "↑↓", Action: "spin"},
+		})
+	}
+
+	lines := make([]string, len(rows))
+	for i, row := range rows {
+		items := make([]string, len(row))
+		for j, content := range row {
+			items[j] = style.HelperStyle.Key.Render(content.Key) + " " +
+				style.HelperStyle.Action.Render(content.Action)
+		}
+		lines[i] = strings.Join(items, "  ")
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Center, lines...)
+}
+
+// addMonths moves t by the months, keeping the day within the target month
+// instead of overflowing into the next one.
+func addMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return time.Date(
+		first.Year(), first.Month(), min(t.Day(), lastDay),
+		t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location(),
+	)
+}
+
+func sameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.Month() == b.Month() && a.Day() == b.Day()
+}
+
+func (d *DatePicker) Value() string {
+	return d.cursor.Format(d.layout())
+}
+
+func (d *DatePicker) Values() []string {
+	return []string{d.Value()}
+}
+
+func (d *DatePicker) SetValues(vals ...string) error {
+	if len(vals) != 1 {
+		return errors.New("DatePicker expects a single value")
+	}
+
+	t, err := time.ParseInLocation(d.layout(), vals[0], time.Local)
+	if err != nil {
+		return err
+	}
+	d.cursor = t
+
+	return nil
+}
+
+func (d *DatePicker) Validate() {
+	if d.ValidateFunc != nil {
+		d.err = d.ValidateFunc(d.Value())
+	}
+}
+
+func (d *DatePicker) Error() string {
+	if d.err != nil {
+		return d.err.Error()
+	}
+	return ""
+}
@@ -7,6 +7,7 @@ import (
 	"net/url"
 
 	"github.com/liuminhaw/yatijapp-tui/internal/authclient"
+	"github.com/liuminhaw/yatijapp-tui/internal/datetime"
 )
 
 type ListActionsResponse struct {
@@ -126,11 +127,17 @@ func GetAction(serverURL, uuid string, client *authclient.AuthClient) (Action, e
 		}
 	}
 
-	responseData.Action.CreatedAt = responseData.Action.CreatedAt.Local()
-	responseData.Action.UpdatedAt = responseData.Action.UpdatedAt.Local()
-	responseData.Action.LastActive = responseData.Action.LastActive.Local()
+	return responseData.Action.inDisplayZone(), nil
+}
+
+// inDisplayZone converts the action timestamps to the display timezone.
+func (a Action) inDisplayZone() Action {
+	f := datetime.Display()
+	a.CreatedAt = f.In(a.CreatedAt)
+	a.UpdatedAt = f.In(a.UpdatedAt)
+	a.LastActive = f.In(a.LastActive)
 
-	return responseData.Action, nil
+	return a
 }
 
 func DeleteAction(serverURL, uuid string, client *authclient.AuthClient) error {
@@ -184,17 +191,18 @@ type ActionRequestBody struct {
 	Status      string `json:"status"`
 }
 
-func (b ActionRequestBody) Create(serverURL string, client *authclient.AuthClient) error {
+// Create creates a new action and returns the action created by the server.
+func (b ActionRequestBody) Create(serverURL string, client *authclient.AuthClient) (Action, error) {
 	path, err := url.JoinPath(serverURL, "v1", "actions")
 	if err != nil {
-		return UnexpectedApiDataErr{
+		return Action{}, UnexpectedApiDataErr{
 			Err: err,
 			Msg: "Failed to create URL path for POST Action",
 		}
 	}
 	data, err := json.Marshal(b)
 	if err != nil {
-		return UnexpectedApiDataErr{
+		return Action{}, UnexpectedApiDataErr{
 			Err: err,
 			Msg: "Failed to create request body JSON",
 		}
@@ -202,7 +210,7 @@ func (b ActionRequestBody) Create(serverURL string, client *authclient.AuthClien
 
 	req, err := http.NewRequest(http.MethodPost, path, bytes.NewBuffer(data))
 	if err != nil {
-		return UnexpectedApiDataErr{
+		return Action{}, UnexpectedApiDataErr{
 			Err: err,
 			Msg: "Failed to create POST request for Action",
 		}
@@ -211,35 +219,44 @@ func (b ActionRequestBody) Create(serverURL string, client *authclient.AuthClien
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return respErrorCheck(err, "API request error: POST Action")
+		return Action{}, respErrorCheck(err, "API request error: POST Action")
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
 		var responseErr ErrorResponse
 		if err := json.NewDecoder(resp.Body).Decode(&responseErr); err != nil {
-			return UnexpectedApiDataErr{
+			return Action{}, UnexpectedApiDataErr{
 				Err: err,
 				Msg: "API error response decode failure",
 			}
 		}
 
-		return UnauthorizedApiDataErr{
+		return Action{}, UnauthorizedApiDataErr{
 			Status: resp.StatusCode,
 			Err:    responseErr,
 			Msg:    responseErr.Error(),
 		}
 	}
 
-	return nil
+	var responseData GetActionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&responseData); err != nil {
+		return Action{}, UnexpectedApiDataErr{
+			Err: err,
+			Msg: "API response decode error",
+		}
+	}
+
+	return responseData.Action.inDisplayZone(), nil
 }
 
+// Update modifies the action and returns the action updated by the server.
 func (b ActionRequestBody) Update(
 	serverURL, uuid string, client *authclient.AuthClient,
-) error {
+) (Action, error) {
 	path, err := url.JoinPath(serverURL, "v1", "actions", uuid)
 	if err != nil {
-		return UnexpectedApiDataErr{
+		return Action{}, UnexpectedApiDataErr{
 			Err: err,
 			Msg: "Failed to create URL path for PATCH Action",
 		}
@@ -247,7 +264,7 @@ func (b ActionRequestBody) Update(
 
 	data, err := json.Marshal(b)
 	if err != nil {
-		return UnexpectedApiDataErr{
+		return Action{}, UnexpectedApiDataErr{
 			Err: err,
 			Msg: "Failed to create request body JSON",
 		}
@@ -255,7 +272,7 @@ func (b ActionRequestBody) Update(
 
 	req, err := http.NewRequest(http.MethodPatch, path, bytes.NewBuffer(data))
 	if err != nil {
-		return UnexpectedApiDataErr{
+		return Action{}, UnexpectedApiDataErr{
 			Err: err,
 			Msg: "Failed to create PATCH request for Action",
 		}
@@ -264,25 +281,33 @@ func (b ActionRequestBody) Update(
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return respErrorCheck(err, "API request error: PATCH Action")
+		return Action{}, respErrorCheck(err, "API request error: PATCH Action")
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		var responseErr ErrorResponse
 		if err := json.NewDecoder(resp.Body).Decode(&responseErr); err != nil {
-			return UnexpectedApiDataErr{
+			return Action{}, UnexpectedApiDataErr{
 				Err: err,
 				Msg: "API error response decode failure",
 			}
 		}
 
-		return UnauthorizedApiDataErr{
+		return Action{}, UnauthorizedApiDataErr{
 			Status: resp.StatusCode,
 			Err:    responseErr,
 			Msg:    responseErr.Error(),
 		}
 	}
 
-	return nil
+	var responseData GetActionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&responseData); err != nil {
+		return Action{}, UnexpectedApiDataErr{
+			Err: err,
+			Msg: "API response decode error",
+		}
+	}
+
+	return responseData.Action.inDisplayZone(), nil
 }
@@ -39,8 +39,12 @@ func (n *Note) Read() ([]byte, error) {
 	return data, nil
 }
 
+// Write replaces the content of the note.
 func (n *Note) Write(content []byte) error {
-	if _, err := n.file.Write(content); err != nil {
+	if err := n.file.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := n.file.WriteAt(content, 0); err != nil {
 		return err
 	}
 	return nil
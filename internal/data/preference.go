@@ -6,15 +6,94 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/liuminhaw/yatijapp-tui/internal/authclient"
+	"github.com/liuminhaw/yatijapp-tui/internal/datetime"
 )
 
 type Filter struct {
 	SortBy    string   `json:"sortBy"`
 	SortOrder string   `json:"sortOrder,omitzero"`
 	Status    []string `json:"status"`
+
+	// Due is one of the due presets, DueCustom for the range of DueFrom and
+	// DueTo. The bounds are kept as typed, relative dates resolve on use.
+	Due     string `json:"due,omitzero"`
+	DueFrom string `json:"dueFrom,omitzero"`
+	DueTo   string `json:"dueTo,omitzero"`
+}
+
+// Due presets of the filter, DueAny leaves the due date unfiltered.
+const (
+	DueAny       = ""
+	DueOverdue   = "overdue"
+	DueToday     = "today"
+	DueThisWeek  = "this week"
+	DueNext7Days = "next 7d"
+	DueNone      = "no due"
+	DueCustom    = "custom"
+)
+
+// DueRange resolves the due preset at now, ok is false when the filter does
+// not narrow down the records by due date. Records without a due date only
+// match the DueNone preset.
+func (f Filter) DueRange(now time.Time) (r datetime.DayRange, ok bool) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch f.Due {
+	case DueOverdue:
+		return datetime.DayRange{To: today.AddDate(0, 0, -1)}, true
+	case DueToday:
+		return datetime.DayRange{From: today, To: today}, true
+	case DueThisWeek:
+		return datetime.Week(now), true
+	case DueNext7Days:
+		return datetime.DayRange{From: today, To: today.AddDate(0, 0, 6)}, true
+	case DueCustom:
+		if t, err := datetime.ParseDate(f.DueFrom, now); err == nil {
+			r.From = t
+		}
+		if t, err := datetime.ParseDate(f.DueTo, now); err == nil {
+			r.To = t
+		}
+		return r, !r.From.IsZero() || !r.To.IsZero()
+	default:
+		return r, false
+	}
+}
+
+// MatchDue reports whether a record with the given due date passes the due
+// filter at now.
+func (f Filter) MatchDue(due time.Time, hasDue bool, now time.Time) bool {
+	if f.Due == DueNone {
+		return !hasDue
+	}
+	r, ok := f.DueRange(now)
+	if !ok {
+		return true
+	}
+	return hasDue && r.Contains(due)
+}
+
+// DueQueryStrings returns the query strings narrowing down the records to
+// the due range, for the API to page through fewer records. The records are
+// matched client-side with MatchDue either way.
+func (f Filter) DueQueryStrings(now time.Time) map[string]string {
+	qs := make(map[string]string)
+	r, ok := f.DueRange(now)
+	if !ok {
+		return qs
+	}
+	if !r.From.IsZero() {
+		qs["due_from"] = r.From.Format(time.DateOnly)
+	}
+	if !r.To.IsZero() {
+		qs["due_to"] = r.To.Format(time.DateOnly)
+	}
+	return qs
 }
 
 // SortKey maps user-friendly sortBy values to API field names.
@@ -63,11 +142,67 @@ type Filters struct {
 	Session Filter `json:"session"`
 }
 
+// View is a saved view, a named search of a record type along with the
+// status set and sort to list the records with.
+type View struct {
+	Name       string     `json:"name"`
+	RecordType RecordType `json:"recordType"`
+	Search     string     `json:"search,omitzero"`
+	Filter     Filter     `json:"filter"`
+}
+
 type Preferences struct {
 	Filters Filters `json:"filters"`
+	Views   []View  `json:"views,omitzero"`
 	Version string  `json:"version"`
 }
 
+// Equal reports whether both views list the same records.
+func (v View) Equal(other View) bool {
+	return v.Name == other.Name &&
+		v.RecordType == other.RecordType &&
+		v.Search == other.Search &&
+		v.Filter.SortKey() == other.Filter.SortKey() &&
+		v.Filter.SortOrder == other.Filter.SortOrder &&
+		slices.Equal(v.Filter.Status, other.Filter.Status) &&
+		v.Filter.Due == other.Filter.Due &&
+		v.Filter.DueFrom == other.Filter.DueFrom &&
+		v.Filter.DueTo == other.Filter.DueTo
+}
+
+// View returns the saved view of the given name.
+func (p Preferences) View(name string) (View, bool) {
+	for _, v := range p.Views {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return View{}, false
+}
+
+// Clone returns a copy of the preferences whose views can be changed apart
+// from p.
+func (p Preferences) Clone() Preferences {
+	p.Views = slices.Clone(p.Views)
+	return p
+}
+
+// SaveView adds the view, replacing the saved view of the same name.
+func (p *Preferences) SaveView(view View) {
+	for i, v := range p.Views {
+		if v.Name == view.Name {
+			p.Views[i] = view
+			return
+		}
+	}
+	p.Views = append(p.Views, view)
+}
+
+// DeleteView removes the saved view of the given name.
+func (p *Preferences) DeleteView(name string) {
+	p.Views = slices.DeleteFunc(p.Views, func(v View) bool { return v.Name == name })
+}
+
 var DefaultPreferences Preferences = Preferences{
 	Filters: Filters{
 		Target: Filter{
@@ -117,46 +252,41 @@ func (p Preferences) GetFilter(recordType RecordType) RecordFilter {
 }
 
 func (p *Preferences) formatApiResponse() {
-	asc := "ascending"
-	desc := "descending"
-	if strings.HasPrefix(p.Filters.Target.SortBy, "-") {
-		p.Filters.Target.SortBy = p.Filters.Target.SortBy[1:]
-		p.Filters.Target.SortOrder = desc
-	} else {
-		p.Filters.Target.SortOrder = asc
-	}
-	if strings.HasPrefix(p.Filters.Action.SortBy, "-") {
-		p.Filters.Action.SortBy = p.Filters.Action.SortBy[1:]
-		p.Filters.Action.SortOrder = desc
-	} else {
-		p.Filters.Action.SortOrder = asc
-	}
-	if strings.HasPrefix(p.Filters.Session.SortBy, "-") {
-		p.Filters.Session.SortBy = p.Filters.Session.SortBy[1:]
-		p.Filters.Session.SortOrder = desc
-	} else {
-		p.Filters.Session.SortOrder = asc
+	p.Filters.Target.formatApiResponse()
+	p.Filters.Action.formatApiResponse()
+	p.Filters.Session.formatApiResponse()
+	for i := range p.Views {
+		p.Views[i].Filter.formatApiResponse()
 	}
 }
 
 func (p *Preferences) formatApiRequest() {
-	p.Filters.Target.SortBy = p.Filters.Target.SortKey()
-	if p.Filters.Target.SortOrder == "descending" {
-		p.Filters.Target.SortBy = "-" + p.Filters.Target.SortBy
+	p.Filters.Target.formatApiRequest()
+	p.Filters.Action.formatApiRequest()
+	p.Filters.Session.formatApiRequest()
+
+	// Keep the views of the caller untouched
+	p.Views = slices.Clone(p.Views)
+	for i := range p.Views {
+		p.Views[i].Filter.formatApiRequest()
 	}
-	p.Filters.Target.SortOrder = ""
+}
 
-	p.Filters.Action.SortBy = p.Filters.Action.SortKey()
-	if p.Filters.Action.SortOrder == "descending" {
-		p.Filters.Action.SortBy = "-" + p.Filters.Action.SortBy
+func (f *Filter) formatApiResponse() {
+	if strings.HasPrefix(f.SortBy, "-") {
+		f.SortBy = f.SortBy[1:]
+		f.SortOrder = "descending"
+	} else {
+		f.SortOrder = "ascending"
 	}
-	p.Filters.Action.SortOrder = ""
+}
 
-	p.Filters.Session.SortBy = p.Filters.Session.SortKey()
-	if p.Filters.Session.SortOrder == "descending" {
-		p.Filters.Session.SortBy = "-" + p.Filters.Session.SortBy
+func (f *Filter) formatApiRequest() {
+	f.SortBy = f.SortKey()
+	if f.SortOrder == "descending" {
+		f.SortBy = "-" + f.SortBy
 	}
-	p.Filters.Session.SortOrder = ""
+	f.SortOrder = ""
 }
 
 type PreferencesResponse struct {
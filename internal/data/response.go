@@ -9,6 +9,7 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/liuminhaw/yatijapp-tui/colors"
+	"github.com/liuminhaw/yatijapp-tui/internal/datetime"
 	"github.com/liuminhaw/yatijapp-tui/internal/style"
 )
 
@@ -192,12 +193,37 @@ func (t Target) ListItemView(hasSrc, chosen bool, width int) string {
 	}, chosen, width)
 }
 
+// ListItemProgressView is the list row of the target with a progress bar of
+// its completed actions.
+func (t Target) ListItemProgressView(completed int64, chosen bool, width int) string {
+	return listPageItemView(listItemData{
+		title:         t.Title,
+		status:        t.Status,
+		childrenCount: t.ActionsCount,
+		childrenDone:  completed,
+		progress:      true,
+	}, chosen, width)
+}
+
 func (t Target) ListItemDetailView(hasSrc bool, width int) string {
+	return listPageItemDetail(t.detailData(), width)
+}
+
+// ListItemDetailProgressView is the detail of the target with the count of
+// its completed actions.
+func (t Target) ListItemDetailProgressView(completed int64, width int) string {
+	d := t.detailData()
+	d.childrenDone = completed
+	d.progress = true
+	return listPageItemDetail(d, width)
+}
+
+func (t Target) detailData() listItemData {
 	var due *time.Time
 	if t.DueDate.Valid {
 		due = &t.DueDate.Time
 	}
-	return listPageItemDetail(listItemData{
+	return listItemData{
 		title:         t.Title,
 		description:   t.Description,
 		status:        t.Status,
@@ -205,7 +231,7 @@ func (t Target) ListItemDetailView(hasSrc bool, width int) string {
 		itemType:      RecordTypeTarget,
 		hasNotes:      t.HasNotes,
 		childrenCount: t.ActionsCount,
-	}, width)
+	}
 }
 
 func (t Target) GetActualType() RecordType { return RecordTypeTarget }
@@ -412,21 +438,41 @@ type listItemData struct {
 	itemType      RecordType
 	hasNotes      bool
 	childrenCount int64
+	childrenDone  int64
+	progress      bool // childrenDone is known
 }
 
+// progressBarWidth is the width of the progress bar of the list rows.
+const progressBarWidth = 10
+
 func listPageItemView(d listItemData, chosen bool, width int) string {
 	var stringBuilder strings.Builder
 
+	showProgress := d.progress && d.childrenCount > 0
+	done := fmt.Sprintf("%d/%d", d.childrenDone, d.childrenCount)
+
 	if chosen {
+		// the bar colors would break the highlight, only the count is shown
+		title := d.title
+		if showProgress {
+			if gap, remain, ok := style.CalculateGap(width-3, d.title, done+" done"); ok {
+				title += strings.Repeat(" ", gap+remain) + done + " done"
+			}
+		}
 		stringBuilder.WriteString(
 			style.StatusTextStyle(d.status).MarginLeft(1).Render("∎") +
 				style.ChoicesStyle["list"].Choice.Width(width-1).
 					Margin(0, 1, 0, 0).
 					Padding(0, 1, 0, 1).
-					Render(d.title) + "\n",
+					Render(title) + "\n",
 		)
 	} else {
-		gap, remain, ok := style.CalculateGap(width-2, d.title, d.status)
+		status := style.StatusTextStyle(d.status).Render(strings.ToLower(d.status))
+		if showProgress {
+			status = style.ProgressBarView(d.childrenDone, d.childrenCount, progressBarWidth) +
+				style.Document.NormalDim.Render(" "+done) + "  " + status
+		}
+		gap, remain, ok := style.CalculateGap(width-2, d.title, status)
 		if !ok {
 			gap = 1
 		}
@@ -436,7 +482,7 @@ func listPageItemView(d listItemData, chosen bool, width int) string {
 				lipgloss.NewStyle().Width(width).Padding(0, 1).Render(
 					style.ChoicesStyle["list"].Choices.Render(d.title)+
 						strings.Repeat(" ", gap+remain)+
-						style.StatusTextStyle(d.status).Render(strings.ToLower(d.status)), //+ "\n",
+						status, //+ "\n",
 				) + "\n",
 		)
 	}
@@ -452,7 +498,7 @@ func listPageItemDetail(d listItemData, width int) string {
 	if d.due == nil {
 		dueValue = "--"
 	} else {
-		dueValue = d.due.Format("2006-01-02")
+		dueValue = datetime.Display().Due(*d.due)
 	}
 	dueValue = style.Document.Normal.Render(dueValue)
 
@@ -512,8 +558,12 @@ func listPageItemDetail(d listItemData, width int) string {
 		statusInfo + statusValue,
 		notesInfo + notesValue,
 	}
+	childrenCountValue := fmt.Sprintf("%d", d.childrenCount)
+	if d.progress {
+		childrenCountValue = fmt.Sprintf("%d/%d done", d.childrenDone, d.childrenCount)
+	}
 	if d.itemType != RecordTypeSession {
-		fields = append(fields, fmt.Sprintf("%s: %d", childrenCountInfo, d.childrenCount))
+		fields = append(fields, childrenCountInfo+childrenCountValue)
 	}
 
 	gap, remain, ok := style.CalculateGap(width-4, fields...)
@@ -528,9 +578,7 @@ func listPageItemDetail(d listItemData, width int) string {
 			lipgloss.NewStyle().
 				Render(
 					style.Document.Primary.Render(childrenCountInfo)+
-						style.Document.Normal.Render(
-							fmt.Sprintf("%d", d.childrenCount),
-						),
+						style.Document.Normal.Render(childrenCountValue),
 				)
 	}
 	fieldsString += strings.Repeat(" ", gap) + notesInfo + notesValue
@@ -553,10 +601,11 @@ func ListPageDetailView(detail string, dim bool) string {
 }
 
 func sessionTitle(startsAt time.Time, endsAt sql.NullTime) string {
-	startStr := startsAt.Local().Format("2006-01-02 15:04:05")
+	f := datetime.Display()
+	startStr := f.Timestamp(startsAt)
 	endStr := "--"
 	if endsAt.Valid {
-		endStr = endsAt.Time.Local().Format("2006-01-02 15:04:05")
+		endStr = f.Timestamp(endsAt.Time)
 	}
 
 	return fmt.Sprintf("%s → %s", startStr, endStr)
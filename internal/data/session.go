@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/liuminhaw/yatijapp-tui/internal/authclient"
+	"github.com/liuminhaw/yatijapp-tui/internal/datetime"
 )
 
 type ListSessionsResponse struct {
@@ -129,14 +130,20 @@ func GetSession(serverURL, uuid string, client *authclient.AuthClient) (Session,
 		}
 	}
 
-	responseData.Session.StartsAt = responseData.Session.StartsAt.Local()
-	responseData.Session.CreatedAt = responseData.Session.CreatedAt.Local()
-	responseData.Session.UpdatedAt = responseData.Session.UpdatedAt.Local()
-	if responseData.Session.EndsAt.Valid {
-		responseData.Session.EndsAt.Time = responseData.Session.EndsAt.Time.Local()
+	return responseData.Session.inDisplayZone(), nil
+}
+
+// inDisplayZone converts the session timestamps to the display timezone.
+func (s Session) inDisplayZone() Session {
+	f := datetime.Display()
+	s.StartsAt = f.In(s.StartsAt)
+	s.CreatedAt = f.In(s.CreatedAt)
+	s.UpdatedAt = f.In(s.UpdatedAt)
+	if s.EndsAt.Valid {
+		s.EndsAt.Time = f.In(s.EndsAt.Time)
 	}
 
-	return responseData.Session, nil
+	return s
 }
 
 func DeleteSession(serverUrl, uuid string, client *authclient.AuthClient) error {
@@ -188,17 +195,21 @@ type SessionRequestBody struct {
 	Notes      *string      `json:"notes"`
 }
 
-func (b SessionRequestBody) Create(serverURL string, client *authclient.AuthClient) error {
+// Create creates a new session and returns the session created by the server.
+func (b SessionRequestBody) Create(
+	serverURL string,
+	client *authclient.AuthClient,
+) (Session, error) {
 	path, err := url.JoinPath(serverURL, "v1", "sessions")
 	if err != nil {
-		return UnexpectedApiDataErr{
+		return Session{}, UnexpectedApiDataErr{
 			Err: err,
 			Msg: "Failed to create URL path for POST Session",
 		}
 	}
 	data, err := json.Marshal(b)
 	if err != nil {
-		return UnexpectedApiDataErr{
+		return Session{}, UnexpectedApiDataErr{
 			Err: err,
 			Msg: "Failed to marshal request body for POST Session",
 		}
@@ -206,7 +217,7 @@ func (b SessionRequestBody) Create(serverURL string, client *authclient.AuthClie
 
 	req, err := http.NewRequest(http.MethodPost, path, bytes.NewBuffer(data))
 	if err != nil {
-		return UnexpectedApiDataErr{
+		return Session{}, UnexpectedApiDataErr{
 			Err: err,
 			Msg: "Failed to create POST request for Session",
 		}
@@ -215,35 +226,44 @@ func (b SessionRequestBody) Create(serverURL string, client *authclient.AuthClie
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return respErrorCheck(err, "API request error: POST Session")
+		return Session{}, respErrorCheck(err, "API request error: POST Session")
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
 		var responseErr ErrorResponse
 		if err := json.NewDecoder(resp.Body).Decode(&responseErr); err != nil {
-			return UnexpectedApiDataErr{
+			return Session{}, UnexpectedApiDataErr{
 				Err: err,
 				Msg: "API error response decode failure",
 			}
 		}
 
-		return UnauthorizedApiDataErr{
+		return Session{}, UnauthorizedApiDataErr{
 			Status: resp.StatusCode,
 			Err:    responseErr,
 			Msg:    responseErr.Error(),
 		}
 	}
 
-	return nil
+	var responseData GetSessionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&responseData); err != nil {
+		return Session{}, UnexpectedApiDataErr{
+			Err: err,
+			Msg: "API response decode error",
+		}
+	}
+
+	return responseData.Session.inDisplayZone(), nil
 }
 
+// Update modifies the session and returns the session updated by the server.
 func (b SessionRequestBody) Update(
 	serverURL, uuid string, client *authclient.AuthClient,
-) error {
+) (Session, error) {
 	path, err := url.JoinPath(serverURL, "v1", "sessions", uuid)
 	if err != nil {
-		return UnexpectedApiDataErr{
+		return Session{}, UnexpectedApiDataErr{
 			Err: err,
 			Msg: "Failed to create URL path for PATCH Session",
 		}
@@ -251,7 +271,7 @@ func (b SessionRequestBody) Update(
 
 	data, err := json.Marshal(b)
 	if err != nil {
-		return UnexpectedApiDataErr{
+		return Session{}, UnexpectedApiDataErr{
 			Err: err,
 			Msg: "Failed to create request body JSON",
 		}
@@ -259,7 +279,7 @@ func (b SessionRequestBody) Update(
 
 	req, err := http.NewRequest(http.MethodPatch, path, bytes.NewBuffer(data))
 	if err != nil {
-		return UnexpectedApiDataErr{
+		return Session{}, UnexpectedApiDataErr{
 			Err: err,
 			Msg: "Failed to create PATCH request for Session",
 		}
@@ -268,25 +288,33 @@ func (b SessionRequestBody) Update(
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return respErrorCheck(err, "API request error: PATCH Session")
+		return Session{}, respErrorCheck(err, "API request error: PATCH Session")
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		var responseErr ErrorResponse
 		if err := json.NewDecoder(resp.Body).Decode(&responseErr); err != nil {
-			return UnexpectedApiDataErr{
+			return Session{}, UnexpectedApiDataErr{
 				Err: err,
 				Msg: "API error response decode failure",
 			}
 		}
 
-		return UnauthorizedApiDataErr{
+		return Session{}, UnauthorizedApiDataErr{
 			Status: resp.StatusCode,
 			Err:    responseErr,
 			Msg:    responseErr.Error(),
 		}
 	}
 
-	return nil
+	var responseData GetSessionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&responseData); err != nil {
+		return Session{}, UnexpectedApiDataErr{
+			Err: err,
+			Msg: "API response decode error",
+		}
+	}
+
+	return responseData.Session.inDisplayZone(), nil
 }
@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/liuminhaw/yatijapp-tui/internal/authclient"
+	"github.com/liuminhaw/yatijapp-tui/internal/datetime"
 )
 
 type ListTargetsResponse struct {
@@ -120,11 +121,17 @@ func GetTarget(serverURL, uuid string, client *authclient.AuthClient) (Target, e
 		}
 	}
 
-	responseData.Target.CreatedAt = responseData.Target.CreatedAt.Local()
-	responseData.Target.UpdatedAt = responseData.Target.UpdatedAt.Local()
-	responseData.Target.LastActive = responseData.Target.LastActive.Local()
+	return responseData.Target.inDisplayZone(), nil
+}
+
+// inDisplayZone converts the target timestamps to the display timezone.
+func (t Target) inDisplayZone() Target {
+	f := datetime.Display()
+	t.CreatedAt = f.In(t.CreatedAt)
+	t.UpdatedAt = f.In(t.UpdatedAt)
+	t.LastActive = f.In(t.LastActive)
 
-	return responseData.Target, nil
+	return t
 }
 
 func DeleteTarget(serverURL, uuid string, client *authclient.AuthClient) error {
@@ -173,10 +180,11 @@ type TargetRequestBody struct {
 	Status      string `json:"status"`
 }
 
-func (b TargetRequestBody) Create(serverURL string, client *authclient.AuthClient) error {
+// Create creates a new target and returns the target created by the server.
+func (b TargetRequestBody) Create(serverURL string, client *authclient.AuthClient) (Target, error) {
 	data, err := json.Marshal(b)
 	if err != nil {
-		return UnexpectedApiDataErr{
+		return Target{}, UnexpectedApiDataErr{
 			Err: err,
 			Msg: "Failed to create request body JSON",
 		}
@@ -188,7 +196,7 @@ func (b TargetRequestBody) Create(serverURL string, client *authclient.AuthClien
 		bytes.NewBuffer(data),
 	)
 	if err != nil {
-		return UnexpectedApiDataErr{
+		return Target{}, UnexpectedApiDataErr{
 			Err: err,
 			Msg: "Failed to create POST request for target",
 		}
@@ -197,37 +205,46 @@ func (b TargetRequestBody) Create(serverURL string, client *authclient.AuthClien
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return respErrorCheck(err, "API request error: POST Target")
+		return Target{}, respErrorCheck(err, "API request error: POST Target")
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
 		var responseErr ErrorResponse
 		if err := json.NewDecoder(resp.Body).Decode(&responseErr); err != nil {
-			return UnexpectedApiDataErr{
+			return Target{}, UnexpectedApiDataErr{
 				Err: err,
 				Msg: "API error response decode failure",
 			}
 		}
 
-		return UnauthorizedApiDataErr{
+		return Target{}, UnauthorizedApiDataErr{
 			Status: resp.StatusCode,
 			Err:    responseErr,
 			Msg:    responseErr.Error(),
 		}
 	}
 
-	return nil
+	var responseData GetTargetResponse
+	if err := json.NewDecoder(resp.Body).Decode(&responseData); err != nil {
+		return Target{}, UnexpectedApiDataErr{
+			Err: err,
+			Msg: "API response decode error",
+		}
+	}
+
+	return responseData.Target.inDisplayZone(), nil
 }
 
+// Update modifies the target and returns the target updated by the server.
 func (b TargetRequestBody) Update(
 	serverURL string,
 	uuid string,
 	client *authclient.AuthClient,
-) error {
+) (Target, error) {
 	data, err := json.Marshal(b)
 	if err != nil {
-		return UnexpectedApiDataErr{
+		return Target{}, UnexpectedApiDataErr{
 			Err: err,
 			Msg: "Failed to create request body JSON",
 		}
@@ -239,7 +256,7 @@ func (b TargetRequestBody) Update(
 		bytes.NewBuffer(data),
 	)
 	if err != nil {
-		return UnexpectedApiDataErr{
+		return Target{}, UnexpectedApiDataErr{
 			Err: err,
 			Msg: "Failed to create PATCH request for target",
 		}
@@ -248,25 +265,33 @@ func (b TargetRequestBody) Update(
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return respErrorCheck(err, "API request error: PATCH Target")
+		return Target{}, respErrorCheck(err, "API request error: PATCH Target")
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		var responseErr ErrorResponse
 		if err := json.NewDecoder(resp.Body).Decode(&responseErr); err != nil {
-			return UnexpectedApiDataErr{
+			return Target{}, UnexpectedApiDataErr{
 				Err: err,
 				Msg: "API error response decode failure",
 			}
 		}
 
-		return UnauthorizedApiDataErr{
+		return Target{}, UnauthorizedApiDataErr{
 			Status: resp.StatusCode,
 			Err:    responseErr,
 			Msg:    responseErr.Error(),
 		}
 	}
 
-	return nil
+	var responseData GetTargetResponse
+	if err := json.NewDecoder(resp.Body).Decode(&responseData); err != nil {
+		return Target{}, UnexpectedApiDataErr{
+			Err: err,
+			Msg: "API response decode error",
+		}
+	}
+
+	return responseData.Target.inDisplayZone(), nil
 }
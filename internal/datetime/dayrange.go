@@ -0,0 +1,44 @@
+package datetime
+
+import "time"
+
+// DayRange is a range of calendar days, From and To included. A zero bound
+// leaves the range open on that side. Days are compared by their calendar
+// date, whatever the location of the times.
+type DayRange struct {
+	From, To time.Time
+}
+
+// Contains reports whether the day of t is within the range.
+func (r DayRange) Contains(t time.Time) bool {
+	day := calendarDay(t)
+	if !r.From.IsZero() && day.Before(calendarDay(r.From)) {
+		return false
+	}
+	if !r.To.IsZero() && day.After(calendarDay(r.To)) {
+		return false
+	}
+	return true
+}
+
+// Week returns the week of now, from monday to sunday.
+func Week(now time.Time) DayRange {
+	today := StartOfDay(now)
+	monday := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+	return DayRange{From: monday, To: monday.AddDate(0, 0, 6)}
+}
+
+// StartOfDay returns midnight of the day of t, in the location of t.
+func StartOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// DaysBetween counts the calendar days from the day of a to the day of b,
+// unaffected by daylight saving changes.
+func DaysBetween(a, b time.Time) int {
+	return int(calendarDay(b).Sub(calendarDay(a)).Hours() / 24)
+}
+
+func calendarDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,215 @@
+package datetime
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// APIDateLayout is the layout of the calendar dates, like due dates,
+// exchanged with the API.
+const APIDateLayout = "2006-01-02"
+
+// Date formats which can be used by name in the configuration, any other
+// value is used as a Go time layout.
+var DateFormats = map[string]string{
+	"iso": APIDateLayout,
+	"dmy": "02/01/2006",
+	"mdy": "01/02/2006",
+}
+
+// Formatter formats times for display, and describes the layouts accepted
+// when the same times are typed back into forms.
+type Formatter struct {
+	Location   *time.Location
+	DateLayout string
+	Clock12    bool
+	Relative   bool // show timestamps relative to now, like "3h ago"
+}
+
+var display = Formatter{
+	Location:   time.Local,
+	DateLayout: DateFormats["iso"],
+}
+
+// NewFormatter creates a formatter from the display preferences. An empty
+// timezone means the local timezone, and an empty date format means iso.
+func NewFormatter(timezone, dateFormat, clock string, relative bool) (Formatter, error) {
+	f := Formatter{
+		Location:   time.Local,
+		DateLayout: DateFormats["iso"],
+		Relative:   relative,
+	}
+
+	if timezone != "" {
+		loc, err := time.LoadLocation(timezone)
+		if err != nil {
+			return Formatter{}, fmt.Errorf("invalid timezone %q: %w", timezone, err)
+		}
+		f.Location = loc
+	}
+
+	if layout, ok := DateFormats[dateFormat]; ok {
+		f.DateLayout = layout
+	} else if dateFormat != "" {
+		f.DateLayout = dateFormat
+	}
+
+	switch clock {
+	case "", "24h":
+	case "12h":
+		f.Clock12 = true
+	default:
+		return Formatter{}, fmt.Errorf("invalid clock %q, expected 12h or 24h", clock)
+	}
+
+	return f, nil
+}
+
+// SetDisplay sets the formatter used by Display.
+func SetDisplay(f Formatter) {
+	display = f
+}
+
+// Display returns the formatter set up from the display preferences.
+func Display() Formatter {
+	return display
+}
+
+// Now returns the current time in the display timezone.
+func Now() time.Time {
+	return time.Now().In(display.Location)
+}
+
+func (f Formatter) In(t time.Time) time.Time {
+	return t.In(f.Location)
+}
+
+func (f Formatter) ClockLayout() string {
+	if f.Clock12 {
+		return "3:04 PM"
+	}
+	return "15:04"
+}
+
+// DateTimeLayout is the layout of a date with the time of day in seconds, as
+// used to fill in and parse form fields.
+func (f Formatter) DateTimeLayout() string {
+	if f.Clock12 {
+		return f.DateLayout + " 3:04:05 PM"
+	}
+	return f.DateLayout + " 15:04:05"
+}
+
+func (f Formatter) inputLayouts() []string {
+	return []string{
+		f.DateTimeLayout(),
+		f.DateLayout + " " + f.ClockLayout(),
+		f.DateLayout,
+	}
+}
+
+func (f Formatter) Date(t time.Time) string {
+	return f.In(t).Format(f.DateLayout)
+}
+
+func (f Formatter) Clock(t time.Time) string {
+	return f.In(t).Format(f.ClockLayout())
+}
+
+func (f Formatter) DateTime(t time.Time) string {
+	return f.In(t).Format(f.DateTimeLayout())
+}
+
+// Timestamp formats the time as a date time, or relative to now in relative
+// mode.
+func (f Formatter) Timestamp(t time.Time) string {
+	if f.Relative {
+		return Relative(t, time.Now())
+	}
+	return f.DateTime(t)
+}
+
+// Detailed formats the time as a date time, followed by the time relative to
+// now in relative mode.
+func (f Formatter) Detailed(t time.Time) string {
+	if f.Relative {
+		return f.DateTime(t) + " (" + Relative(t, time.Now()) + ")"
+	}
+	return f.DateTime(t)
+}
+
+// DueDate formats a due date. Due dates are calendar dates, they are not
+// converted to the display timezone.
+func (f Formatter) DueDate(due time.Time) string {
+	return due.Format(f.DateLayout)
+}
+
+// ParseAPIDate parses a calendar date in APIDateLayout. Like due dates, it is
+// not tied to a timezone and is kept in UTC.
+func ParseAPIDate(s string) (time.Time, error) {
+	return time.ParseInLocation(APIDateLayout, s, time.UTC)
+}
+
+// Due formats a due date like DueDate, in relative mode counted in days like
+// "in 2d".
+func (f Formatter) Due(due time.Time) string {
+	if !f.Relative {
+		return f.DueDate(due)
+	}
+
+	now := f.In(time.Now())
+	days := int(math.Round(
+		time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.UTC).
+			Sub(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)).Hours() / 24,
+	))
+
+	switch {
+	case days == 0:
+		return "today"
+	case days == 1:
+		return "tomorrow"
+	case days == -1:
+		return "yesterday"
+	case days > 0:
+		return fmt.Sprintf("in %dd", days)
+	default:
+		return fmt.Sprintf("%dd ago", -days)
+	}
+}
+
+// Relative formats t relative to now, like "3h ago" or "in 2d".
+func Relative(t, now time.Time) string {
+	d := t.Sub(now)
+	future := d > 0
+	if !future {
+		d = -d
+	}
+	if d < time.Minute {
+		return "just now"
+	}
+
+	s := ShortDuration(d)
+	if future {
+		return "in " + s
+	}
+	return s + " ago"
+}
+
+// ShortDuration formats the duration with its largest unit, like 3h or 2d.
+func ShortDuration(d time.Duration) string {
+	const day = 24 * time.Hour
+
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < day:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	case d < 30*day:
+		return fmt.Sprintf("%dd", int(d/day))
+	case d < 365*day:
+		return fmt.Sprintf("%dmo", int(d/(30*day)))
+	default:
+		return fmt.Sprintf("%dy", int(d/(365*day)))
+	}
+}
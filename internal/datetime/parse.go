@@ -0,0 +1,282 @@
+package datetime
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalid = errors.New("invalid date")
+
+var (
+	dateLayouts     = []string{APIDateLayout}
+	dateTimeLayouts = []string{"2006-01-02 15:04:05", "2006-01-02 15:04"}
+
+	clockRX    = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(?::(\d{2}))?(am|pm)?$`)
+	meridiemRX = regexp.MustCompile(`(\d) (am|pm)\b`)
+	offsetRX   = regexp.MustCompile(`^([+-])(\d+)\s*(mo|[mhdwy])$`)
+	inRX       = regexp.MustCompile(`^in\s+(\d+)\s*([a-z]+)$`)
+	agoRX      = regexp.MustCompile(`^(\d+)\s*([a-z]+)\s+ago$`)
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// Parse resolves the input to an absolute time relative to now. Besides the
+// absolute "YYYY-mm-dd" and "YYYY-mm-dd HH:MM[:SS]" formats, and the formats
+// of the display formatter, it accepts:
+//
+//   - day words: now, today, tomorrow, yesterday, eow, eom, eoy
+//   - weekdays: fri (today if it is a friday), next fri, last fri
+//   - offsets: +3d, -45m, +2w, +1mo, in 2 weeks, 3 days ago
+//   - time of day, alone or after any day: 14:30, 9am, yesterday 9:30pm
+//
+// Days without a time of day resolve to the start of the day, offsets keep
+// the time of day of now.
+func Parse(input string, now time.Time) (time.Time, error) {
+	input = strings.Join(strings.Fields(input), " ")
+	for _, layout := range display.inputLayouts() {
+		if t, err := time.ParseInLocation(layout, input, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	input = strings.ToLower(input)
+	input = meridiemRX.ReplaceAllString(input, "$1$2")
+	if input == "" {
+		return time.Time{}, ErrInvalid
+	}
+
+	if input == "now" {
+		return now.Truncate(time.Second), nil
+	}
+	for _, layout := range dateTimeLayouts {
+		if t, err := time.ParseInLocation(layout, input, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	if t, ok := parseOffset(input, now); ok {
+		return t, nil
+	}
+
+	// A trailing time of day applies to the day expression before it
+	dayPart, clock := input, ""
+	if _, _, _, ok := parseClock(input); ok {
+		dayPart, clock = "today", input
+	} else if i := strings.LastIndex(input, " "); i >= 0 {
+		if _, _, _, ok := parseClock(input[i+1:]); ok {
+			dayPart, clock = input[:i], input[i+1:]
+		}
+	}
+
+	day, err := parseDay(dayPart, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if clock == "" {
+		return day, nil
+	}
+
+	h, m, s, _ := parseClock(clock)
+	return time.Date(day.Year(), day.Month(), day.Day(), h, m, s, 0, day.Location()), nil
+}
+
+// ParseDate resolves the input to a day relative to now. It accepts the days
+// understood by Parse, but no time of day: "tomorrow 15:00" or "+2h" are not
+// dates.
+func ParseDate(input string, now time.Time) (time.Time, error) {
+	input = strings.Join(strings.Fields(input), " ")
+	if t, err := time.ParseInLocation(display.DateLayout, input, now.Location()); err == nil {
+		return t, nil
+	}
+
+	input = strings.ToLower(input)
+	if input == "now" {
+		return StartOfDay(now), nil
+	}
+	return parseDay(input, now)
+}
+
+func parseDay(input string, now time.Time) (time.Time, error) {
+	today := StartOfDay(now)
+
+	for _, layout := range append([]string{display.DateLayout}, dateLayouts...) {
+		if t, err := time.ParseInLocation(layout, input, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	switch input {
+	case "today":
+		return today, nil
+	case "tomorrow", "tmr":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	case "eow":
+		return today.AddDate(0, 0, (7-int(today.Weekday()))%7), nil
+	case "eom":
+		return today.AddDate(0, 1, -today.Day()), nil
+	case "eoy":
+		return time.Date(today.Year(), time.December, 31, 0, 0, 0, 0, today.Location()), nil
+	}
+
+	if n, unit, ok := splitOffset(input); ok && !clockUnit(unit) {
+		if t, ok := addOffset(now, n, unit); ok {
+			return StartOfDay(t), nil
+		}
+	}
+
+	words := strings.Fields(input)
+	switch {
+	case len(words) == 1:
+		if wd, ok := weekdays[words[0]]; ok {
+			return today.AddDate(0, 0, (int(wd)-int(today.Weekday())+7)%7), nil
+		}
+	case len(words) == 2 && words[0] == "next":
+		if wd, ok := weekdays[words[1]]; ok {
+			days := (int(wd) - int(today.Weekday()) + 7) % 7
+			if days == 0 {
+				days = 7
+			}
+			return today.AddDate(0, 0, days), nil
+		}
+	case len(words) == 2 && words[0] == "last":
+		if wd, ok := weekdays[words[1]]; ok {
+			days := (int(today.Weekday()) - int(wd) + 7) % 7
+			if days == 0 {
+				days = 7
+			}
+			return today.AddDate(0, 0, -days), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%w: %q", ErrInvalid, input)
+}
+
+// parseOffset parses "+3d", "-45m", "in 2 weeks" and "3 days ago".
+func parseOffset(input string, now time.Time) (time.Time, bool) {
+	n, unit, ok := splitOffset(input)
+	if !ok {
+		return time.Time{}, false
+	}
+	return addOffset(now, n, unit)
+}
+
+// splitOffset returns the signed amount and the unit of an offset.
+func splitOffset(input string) (int, string, bool) {
+	var sign, amount int
+	var unit string
+
+	if m := offsetRX.FindStringSubmatch(input); m != nil {
+		sign = 1
+		if m[1] == "-" {
+			sign = -1
+		}
+		amount, _ = strconv.Atoi(m[2])
+		unit = m[3]
+	} else if m := inRX.FindStringSubmatch(input); m != nil {
+		sign = 1
+		amount, _ = strconv.Atoi(m[1])
+		unit = m[2]
+	} else if m := agoRX.FindStringSubmatch(input); m != nil {
+		sign = -1
+		amount, _ = strconv.Atoi(m[1])
+		unit = m[2]
+	} else {
+		return 0, "", false
+	}
+	return sign * amount, unit, true
+}
+
+// clockUnit reports whether the offset unit is shorter than a day.
+func clockUnit(unit string) bool {
+	t, ok := addOffset(time.Time{}, 1, unit)
+	return ok && t.Sub(time.Time{}) < 24*time.Hour
+}
+
+func addOffset(now time.Time, n int, unit string) (time.Time, bool) {
+	switch unit {
+	case "m", "min", "mins", "minute", "minutes":
+		return now.Add(time.Duration(n) * time.Minute), true
+	case "h", "hr", "hrs", "hour", "hours":
+		return now.Add(time.Duration(n) * time.Hour), true
+	case "d", "day", "days":
+		return now.AddDate(0, 0, n), true
+	case "w", "wk", "wks", "week", "weeks":
+		return now.AddDate(0, 0, 7*n), true
+	case "mo", "month", "months":
+		return now.AddDate(0, n, 0), true
+	case "y", "yr", "yrs", "year", "years":
+		return now.AddDate(n, 0, 0), true
+	}
+
+	return time.Time{}, false
+}
+
+// parseClock parses a time of day such as "14:30", "14:30:15", "9am" or
+// "9:30pm".
+func parseClock(input string) (hour, minute, second int, ok bool) {
+	m := clockRX.FindStringSubmatch(input)
+	if m == nil {
+		return 0, 0, 0, false
+	}
+	// A bare number is not a time of day without the am/pm suffix
+	if m[2] == "" && m[4] == "" {
+		return 0, 0, 0, false
+	}
+
+	hour, _ = strconv.Atoi(m[1])
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2])
+	}
+	if m[3] != "" {
+		second, _ = strconv.Atoi(m[3])
+	}
+
+	switch m[4] {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, 0, false
+		}
+		if hour == 12 {
+			hour = 0
+		}
+		if m[4] == "pm" {
+			hour += 12
+		}
+	}
+
+	if hour > 23 || minute > 59 || second > 59 {
+		return 0, 0, 0, false
+	}
+
+	return hour, minute, second, true
+}
+
+// ParseDuration parses a positive duration such as "1h30m", "90m", "1.5h" or
+// "1h 30m". A bare number is taken as minutes.
+func ParseDuration(input string) (time.Duration, error) {
+	input = strings.ToLower(strings.Join(strings.Fields(input), ""))
+	if _, err := strconv.ParseFloat(input, 64); err == nil {
+		input += "m"
+	}
+
+	d, err := time.ParseDuration(input)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid duration %q", input)
+	}
+
+	return d, nil
+}
@@ -0,0 +1,137 @@
+package datetime
+
+import (
+	"testing"
+	"time"
+)
+
+// now is a sunday afternoon.
+var now = time.Date(2025, time.June, 15, 14, 20, 30, 0, time.UTC)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func at(year int, month time.Month, d, hour, minute, second int) time.Time {
+	return time.Date(year, month, d, hour, minute, second, 0, time.UTC)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  time.Time
+		err   bool
+	}{
+		{input: "2025-06-20", want: day(2025, time.June, 20)},
+		{input: "2025-06-20 09:30", want: at(2025, time.June, 20, 9, 30, 0)},
+		{input: "2025-06-20  09:30:15", want: at(2025, time.June, 20, 9, 30, 15)},
+		{input: "now", want: now},
+		{input: "today", want: day(2025, time.June, 15)},
+		{input: "Tomorrow", want: day(2025, time.June, 16)},
+		{input: "yesterday 9:30pm", want: at(2025, time.June, 14, 21, 30, 0)},
+		{input: "eow", want: day(2025, time.June, 15)},
+		{input: "eom", want: day(2025, time.June, 30)},
+		{input: "eoy", want: day(2025, time.December, 31)},
+		{input: "fri", want: day(2025, time.June, 20)},
+		{input: "sun", want: day(2025, time.June, 15)},
+		{input: "next sun", want: day(2025, time.June, 22)},
+		{input: "last fri 8am", want: at(2025, time.June, 13, 8, 0, 0)},
+		{input: "+3d", want: at(2025, time.June, 18, 14, 20, 30)},
+		{input: "-45m", want: at(2025, time.June, 15, 13, 35, 30)},
+		{input: "in 2 weeks", want: at(2025, time.June, 29, 14, 20, 30)},
+		{input: "3 days ago", want: at(2025, time.June, 12, 14, 20, 30)},
+		{input: "+1mo", want: at(2025, time.July, 15, 14, 20, 30)},
+		{input: "14:30", want: at(2025, time.June, 15, 14, 30, 0)},
+		{input: "9 am", want: at(2025, time.June, 15, 9, 0, 0)},
+		{input: "", err: true},
+		{input: "someday", err: true},
+		{input: "25:00", err: true},
+		{input: "13pm", err: true},
+		{input: "+3x", err: true},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.input, now)
+		if tt.err {
+			if err == nil {
+				t.Errorf("Parse(%q) = %v, want an error", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v", tt.input, err)
+		} else if !got.Equal(tt.want) {
+			t.Errorf("Parse(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		input string
+		want  time.Time
+		err   bool
+	}{
+		{input: "2025-06-20", want: day(2025, time.June, 20)},
+		{input: "now", want: day(2025, time.June, 15)},
+		{input: "tomorrow", want: day(2025, time.June, 16)},
+		{input: "next fri", want: day(2025, time.June, 20)},
+		{input: "+3d", want: day(2025, time.June, 18)},
+		{input: "in 2 weeks", want: day(2025, time.June, 29)},
+		{input: "-1y", want: day(2024, time.June, 15)},
+		{input: "tomorrow 15:00", err: true},
+		{input: "2025-06-20 09:30", err: true},
+		{input: "14:30", err: true},
+		{input: "+2h", err: true},
+		{input: "in 30 minutes", err: true},
+		{input: "", err: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDate(tt.input, now)
+		if tt.err {
+			if err == nil {
+				t.Errorf("ParseDate(%q) = %v, want an error", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseDate(%q) error = %v", tt.input, err)
+		} else if !got.Equal(tt.want) {
+			t.Errorf("ParseDate(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input string
+		want  time.Duration
+		err   bool
+	}{
+		{input: "1h30m", want: 90 * time.Minute},
+		{input: "1h 30m", want: 90 * time.Minute},
+		{input: "90", want: 90 * time.Minute},
+		{input: "1.5h", want: 90 * time.Minute},
+		{input: "45M", want: 45 * time.Minute},
+		{input: "0", err: true},
+		{input: "-1h", err: true},
+		{input: "", err: true},
+		{input: "soon", err: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.input)
+		if tt.err {
+			if err == nil {
+				t.Errorf("ParseDuration(%q) = %v, want an error", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseDuration(%q) error = %v", tt.input, err)
+		} else if got != tt.want {
+			t.Errorf("ParseDuration(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// Package history keeps a local history of the notes of records, one JSON
+// lines file per record.
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaxRevisions is the number of revisions kept for each record, older
+// revisions are dropped first.
+const MaxRevisions = 50
+
+type Revision struct {
+	At   time.Time `json:"at"`
+	Note string    `json:"note"`
+}
+
+// Store keeps the note revisions in files under Dir.
+type Store struct {
+	Dir string
+}
+
+var mu sync.Mutex
+
+func (s Store) path(uuid string) (string, error) {
+	if uuid == "" || strings.ContainsAny(uuid, `/\.`) {
+		return "", errors.New("invalid record uuid: " + uuid)
+	}
+	return filepath.Join(s.Dir, uuid+".jsonl"), nil
+}
+
+// Revisions returns the revisions of the record note, oldest first.
+func (s Store) Revisions(uuid string) ([]Revision, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return s.revisions(uuid)
+}
+
+func (s Store) revisions(uuid string) ([]Revision, error) {
+	path, err := s.path(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var revisions []Revision
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var r Revision
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, r)
+	}
+
+	return revisions, scanner.Err()
+}
+
+// Save records the note as the latest revision of the record. Nothing is
+// saved if the note matches the latest revision, or if it is empty with no
+// revision saved yet.
+func (s Store) Save(uuid, note string, at time.Time) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	revisions, err := s.revisions(uuid)
+	if err != nil {
+		return err
+	}
+	if len(revisions) == 0 && note == "" {
+		return nil
+	}
+	if len(revisions) > 0 && revisions[len(revisions)-1].Note == note {
+		return nil
+	}
+
+	revisions = append(revisions, Revision{At: at, Note: note})
+	if len(revisions) > MaxRevisions {
+		revisions = revisions[len(revisions)-MaxRevisions:]
+	}
+
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	for _, r := range revisions {
+		if err := encoder.Encode(r); err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(s.Dir, 0o700); err != nil {
+		return err
+	}
+	path, _ := s.path(uuid)
+
+	return os.WriteFile(path, b.Bytes(), 0o600)
+}
@@ -0,0 +1,86 @@
+package hook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+	"time"
+)
+
+const DefaultTimeout = 10 * time.Second
+
+const (
+	TargetCreated       = "target.created"
+	TargetUpdated       = "target.updated"
+	ActionCreated       = "action.created"
+	ActionUpdated       = "action.updated"
+	ActionStatusChanged = "action.status_changed"
+	SessionStarted      = "session.started"
+	SessionUpdated      = "session.updated"
+	SessionEnded        = "session.ended"
+	RecordDeleted       = "record.deleted"
+)
+
+// Hook is an external command run on record lifecycle events, configured as
+// [[hooks]] tables in the configuration file. Event is matched as a path
+// pattern, so "action.*" or "*" can be used to match several events.
+type Hook struct {
+	Event   string        `mapstructure:"event"`
+	Command string        `mapstructure:"command"`
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+func (h Hook) Match(event string) bool {
+	matched, err := path.Match(h.Event, event)
+	return err == nil && matched
+}
+
+// Event describes a change made to a record. Record is the record after the
+// change and Previous the record before the change, either of them can be
+// nil when not known.
+type Event struct {
+	Name       string `json:"event"`
+	RecordType string `json:"record_type"`
+	UUID       string `json:"uuid"`
+	Record     any    `json:"record"`
+	Previous   any    `json:"previous"`
+}
+
+// Run runs the hook command with the event as JSON on stdin. The event name,
+// record type and uuid are also passed in as YATIJAPP_* environment variables.
+// The combined output of the command is returned.
+func (h Hook) Run(ctx context.Context, e Event, env ...string) (string, error) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return "", fmt.Errorf("marshal hook payload: %w", err)
+	}
+
+	timeout := h.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", h.Command)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(),
+		"YATIJAPP_EVENT="+e.Name,
+		"YATIJAPP_RECORD_TYPE="+e.RecordType,
+		"YATIJAPP_RECORD_UUID="+e.UUID,
+	)
+	cmd.Env = append(cmd.Env, env...)
+	cmd.WaitDelay = time.Second
+
+	output, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return string(output), fmt.Errorf("hook timed out after %s", timeout)
+	}
+
+	return strings.TrimSpace(string(output)), err
+}
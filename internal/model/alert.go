@@ -10,15 +10,23 @@ import (
 
 type Alert struct {
 	title     string
-	alertType string // "confirmation", "notification"
+	alertType string // "confirmation", "notification", "options"
 
 	prompts  []string
 	warnings []string
 	cmds     map[string]tea.Cmd
+	options  []AlertOption
 
 	width int
 }
 
+// AlertOption is a choice of an options alert, picked by pressing its key.
+type AlertOption struct {
+	Key   string
+	Label string
+	Cmd   tea.Cmd
+}
+
 func NewAlert(
 	title, alertType string,
 	prompts, warnings []string,
@@ -35,6 +43,24 @@ func NewAlert(
 	}
 }
 
+// NewOptionsAlert creates an alert offering several options, the options are
+// listed in the given order.
+func NewOptionsAlert(
+	title string,
+	prompts, warnings []string,
+	width int,
+	options []AlertOption,
+) Alert {
+	return Alert{
+		title:     title,
+		alertType: "options",
+		prompts:   prompts,
+		warnings:  warnings,
+		options:   options,
+		width:     width,
+	}
+}
+
 func (a Alert) Init() tea.Cmd {
 	return nil
 }
@@ -59,6 +85,15 @@ func (a Alert) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return a, a.cmds["return"]
 			}
 		}
+	case "options":
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			for _, option := range a.options {
+				if strings.EqualFold(msg.String(), option.Key) {
+					return a, option.Cmd
+				}
+			}
+		}
 	default:
 		panic("unknown alert type: " + a.alertType)
 	}
@@ -83,6 +118,13 @@ func (a Alert) View() string {
 			lipgloss.Range{Start: 0, End: 1, Style: style.Document.Primary},
 			lipgloss.Range{Start: 2, End: 6, Style: style.Document.Normal},
 		)
+	case "options":
+		items := make([]string, len(a.options))
+		for i, option := range a.options {
+			items[i] = style.Document.Primary.Render("["+option.Key+"] ") +
+				style.Document.Normal.Render(option.Label)
+		}
+		helper = strings.Join(items, "    ")
 	default:
 		panic("unknown alert type: " + a.alertType)
 	}
@@ -13,6 +13,7 @@ var (
 	SessionStatusOptions = []string{"in progress", "completed"}
 	SessionSortByOptions = []string{"starts at", "updated at"}
 	SortOrderOptions     = []string{"ascending", "descending"}
+	DueOptions           = []string{"any", "overdue", "today", "this week", "next 7d", "no due", "custom"}
 )
 
 type RadioModel struct {
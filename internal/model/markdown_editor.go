@@ -0,0 +1,287 @@
+package model
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/liuminhaw/yatijapp-tui/internal/style"
+)
+
+const markdownIndent = "  "
+
+// listItemRX matches the marker of a markdown list item: the indentation, the
+// bullet or number, and an optional checkbox.
+var listItemRX = regexp.MustCompile(`^(\s*)([-*+]|(\d+)([.)]))( \[[ xX]\])?( +|$)`)
+
+type (
+	// ShowMarkdownEditorMsg asks the page to open the built-in editor for the
+	// focused field.
+	ShowMarkdownEditorMsg struct {
+		Title     string
+		Value     string
+		CharLimit int
+	}
+	MarkdownEditorSavedMsg struct {
+		Value string
+	}
+	MarkdownEditorCanceledMsg struct{}
+)
+
+// MarkdownEditor is a multi-line editor popup for markdown content, with list
+// continuation, indentation, checkbox toggling and a rendered preview.
+type MarkdownEditor struct {
+	title    string
+	textarea textarea.Model
+	preview  bool
+	viewport viewport.Model
+
+	width int
+	err   error
+}
+
+func NewMarkdownEditor(title, value string, width, height, charLimit int) *MarkdownEditor {
+	ta := textarea.New()
+	ta.CharLimit = charLimit
+	ta.ShowLineNumbers = false
+	ta.Prompt = ""
+	// the default cuts notes at 99 lines, 0 leaves only the textarea limit of
+	// 10000 lines
+	ta.MaxHeight = 0
+	ta.SetWidth(width)
+	ta.SetHeight(height)
+	ta.SetValue(value)
+	ta.Focus()
+
+	return &MarkdownEditor{
+		title:    title,
+		textarea: ta,
+		viewport: viewport.New(width, height),
+		width:    width,
+	}
+}
+
+func (e *MarkdownEditor) Init() tea.Cmd {
+	return textarea.Blink
+}
+
+func (e *MarkdownEditor) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		var cmd tea.Cmd
+		e.textarea, cmd = e.textarea.Update(msg)
+		return e, cmd
+	}
+
+	switch keyMsg.String() {
+	case "esc":
+		return e, func() tea.Msg { return MarkdownEditorCanceledMsg{} }
+	case "ctrl+s":
+		value := e.textarea.Value()
+		return e, func() tea.Msg { return MarkdownEditorSavedMsg{Value: value} }
+	case "ctrl+p":
+		e.preview = !e.preview
+		if e.preview {
+			e.renderPreview()
+		}
+		return e, nil
+	}
+
+	if e.preview {
+		var cmd tea.Cmd
+		e.viewport, cmd = e.viewport.Update(msg)
+		return e, cmd
+	}
+
+	switch keyMsg.String() {
+	case "enter":
+		if e.continueList() {
+			return e, nil
+		}
+	case "tab":
+		e.editLine(func(line string, col int) (string, int) {
+			return markdownIndent + line, col + len(markdownIndent)
+		})
+		return e, nil
+	case "shift+tab":
+		e.editLine(outdent)
+		return e, nil
+	case "ctrl+x":
+		e.editLine(toggleCheckbox)
+		return e, nil
+	}
+
+	var cmd tea.Cmd
+	e.textarea, cmd = e.textarea.Update(msg)
+	return e, cmd
+}
+
+// cursor returns the row and the column of the cursor within the row.
+func (e *MarkdownEditor) cursor() (int, int) {
+	info := e.textarea.LineInfo()
+	return e.textarea.Line(), info.StartColumn + info.ColumnOffset
+}
+
+func (e *MarkdownEditor) currentLine() string {
+	row, _ := e.cursor()
+	return strings.Split(e.textarea.Value(), "\n")[row]
+}
+
+// editLine replaces the line under the cursor, edit returns the new line and
+// the new cursor column.
+func (e *MarkdownEditor) editLine(edit func(line string, col int) (string, int)) {
+	row, col := e.cursor()
+	lines := strings.Split(e.textarea.Value(), "\n")
+	lines[row], col = edit(lines[row], col)
+
+	e.textarea.SetValue(strings.Join(lines, "\n"))
+	for e.textarea.Line() > row {
+		e.textarea.CursorUp()
+	}
+	e.textarea.SetCursor(col)
+}
+
+// continueList starts a new list item when enter is pressed on a list item,
+// or ends the list when the item is empty. It reports whether the key was
+// handled.
+func (e *MarkdownEditor) continueList() bool {
+	line := e.currentLine()
+	_, col := e.cursor()
+
+	marker := listItemRX.FindString(line)
+	if marker == "" || col < len([]rune(marker)) {
+		return false
+	}
+
+	if strings.TrimSpace(line) == strings.TrimSpace(marker) {
+		e.editLine(func(string, int) (string, int) { return "", 0 })
+		return true
+	}
+
+	e.textarea.InsertString("\n" + nextListMarker(line))
+	return true
+}
+
+// nextListMarker returns the marker of the list item following the line,
+// numbers are incremented and checkboxes are unchecked.
+func nextListMarker(line string) string {
+	m := listItemRX.FindStringSubmatch(line)
+	if m == nil {
+		return ""
+	}
+
+	marker := m[2]
+	if m[3] != "" {
+		n, _ := strconv.Atoi(m[3])
+		marker = strconv.Itoa(n+1) + m[4]
+	}
+	if m[5] != "" {
+		marker += " [ ]"
+	}
+
+	return m[1] + marker + " "
+}
+
+func outdent(line string, col int) (string, int) {
+	removed := 0
+	for removed < len(markdownIndent) && strings.HasPrefix(line[removed:], " ") {
+		removed++
+	}
+	if removed == 0 && strings.HasPrefix(line, "\t") {
+		removed = 1
+	}
+
+	return line[removed:], max(col-removed, 0)
+}
+
+// toggleCheckbox checks or unchecks the checkbox of the list item, items
+// without a checkbox get an unchecked one and other lines become one.
+func toggleCheckbox(line string, col int) (string, int) {
+	m := listItemRX.FindStringSubmatchIndex(line)
+	if m == nil {
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		return line[:indent] + "- [ ] " + line[indent:], col + len("- [ ] ")
+	}
+
+	if m[10] < 0 {
+		// no checkbox, add one after the bullet
+		return line[:m[5]] + " [ ]" + line[m[5]:], col + len(" [ ]")
+	}
+
+	checkbox := line[m[10]:m[11]]
+	toggled := " [x]"
+	if checkbox != " [ ]" {
+		toggled = " [ ]"
+	}
+	return line[:m[10]] + toggled + line[m[11]:], col
+}
+
+func (e *MarkdownEditor) renderPreview() {
+	renderer, err := glamour.NewTermRenderer(
+		glamour.WithAutoStyle(),
+		glamour.WithWordWrap(e.width-2),
+		glamour.WithEmoji(),
+	)
+	if err != nil {
+		e.err = err
+		return
+	}
+
+	content, err := renderer.Render(e.textarea.Value())
+	if err != nil {
+		e.err = err
+		return
+	}
+	e.err = nil
+	e.viewport.SetContent(content)
+	e.viewport.GotoTop()
+}
+
+func (e *MarkdownEditor) View() string {
+	mode := "edit"
+	body := e.textarea.View()
+	if e.preview {
+		mode = "preview"
+		body = e.viewport.View()
+	}
+
+	title := style.Document.Secondary.Bold(true).Render(e.title) + " " +
+		style.Document.NormalDim.Render("("+mode+")")
+
+	var helper []style.HelperContent
+	if e.preview {
+		helper = []style.HelperContent{
+			{Key: "↑/↓", Action: "scroll"},
+			{Key: "<C-p>", Action: "edit"},
+		}
+	} else {
+		helper = []style.HelperContent{
+			{Key: "Tab", Action: "indent"},
+			{Key: "<C-x>", Action: "checkbox"},
+			{Key: "<C-p>", Action: "preview"},
+		}
+	}
+	helper = append(helper,
+		style.HelperContent{Key: "<C-s>", Action: "save"},
+		style.HelperContent{Key: "Esc", Action: "cancel"},
+	)
+
+	sections := []string{title, "", body}
+	if e.err != nil {
+		sections = append(sections, style.ErrorStyle.Render(e.err.Error()))
+	}
+	sections = append(sections, style.HelperView(helper, e.width))
+
+	return style.BorderStyle["highlighted"].
+		Padding(0, 1).
+		Render(lipgloss.JoinVertical(lipgloss.Left, sections...))
+}
+
+func (e *MarkdownEditor) Value() string {
+	return e.textarea.Value()
+}
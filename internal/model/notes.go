@@ -2,29 +2,55 @@ package model
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"os/exec"
+	"strings"
+	"unicode/utf8"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/liuminhaw/yatijapp-tui/internal/data"
+	"github.com/liuminhaw/yatijapp-tui/internal/style"
 )
 
 type (
+	// ShowNoteTemplatesMsg asks the page to offer note templates before the
+	// note is opened in the editor.
+	ShowNoteTemplatesMsg struct {
+		Inline bool
+	}
 	EditorFinishedMsg struct {
 		err error
 	}
 )
 
+// NoteModel is a multi-line markdown field, edited with the external editor
+// or the built-in one.
 type NoteModel struct {
 	note    *data.Note
 	content string
 	focused bool
 
 	err error
+
+	// Title names the field in the built-in editor
+	Title string
+	// PreviewLines is the number of content lines shown, 0 shows a summary
+	PreviewLines int
+	// Width truncates the content lines shown, 0 does not truncate
+	Width int
+	// CharLimit limits the content typed in the built-in editor, 0 means no
+	// limit
+	CharLimit    int
+	ValidateFunc func(string) error
+	// OfferTemplates asks the page to offer note templates the first time the
+	// empty note is opened
+	OfferTemplates bool
 }
 
 func NewNoteModel() *NoteModel {
-	return &NoteModel{}
+	return &NoteModel{Title: "Note"}
 }
 
 func (m *NoteModel) Init() tea.Cmd {
@@ -34,6 +60,10 @@ func (m *NoteModel) Init() tea.Cmd {
 func (m *NoteModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case EditorFinishedMsg:
+		// Only the focused field has its content opened in the editor
+		if !m.focused || m.note == nil {
+			return m, nil
+		}
 		if msg.err != nil {
 			m.err = msg.err
 			return m, nil
@@ -46,28 +76,67 @@ func (m *NoteModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.content = string(content)
 	case tea.KeyMsg:
 		switch msg.String() {
-		case "e":
+		case "e", "i":
 			if !m.focused {
 				return m, nil
 			}
-			if m.note == nil {
-				note, err := data.NewTempNote("model")
-				if err != nil {
-					m.err = errors.New("failed to create note")
-					return m, nil
-				}
-				m.note = note
+			inline := msg.String() == "i"
+			if m.OfferTemplates && m.content == "" {
+				m.OfferTemplates = false
+				return m, func() tea.Msg { return ShowNoteTemplatesMsg{Inline: inline} }
 			}
-			return m, OpenEditor(m.note.Path())
+			return m, m.Edit(inline)
 		}
 	}
 
 	return m, nil
 }
 
+// Edit opens the content in the built-in editor when inline, otherwise in the
+// external editor.
+func (m *NoteModel) Edit(inline bool) tea.Cmd {
+	if inline {
+		return func() tea.Msg {
+			return ShowMarkdownEditorMsg{Title: m.Title, Value: m.content, CharLimit: m.CharLimit}
+		}
+	}
+
+	if m.note == nil {
+		note, err := data.NewTempNote("model")
+		if err != nil {
+			m.err = errors.New("failed to create note")
+			return nil
+		}
+		m.note = note
+	}
+	return OpenEditor(m.note.Path())
+}
+
+// View shows the first lines of the content, or a summary of it when no
+// preview lines are set.
 func (m *NoteModel) View() string {
-	// TODO: add view in glamour if needed in the future
-	return ""
+	content := strings.TrimRight(m.content, "\n")
+	if content == "" {
+		return style.Document.NormalDim.Render("(empty)")
+	}
+
+	lines := strings.Split(content, "\n")
+	if m.PreviewLines == 0 {
+		summary := fmt.Sprintf("%d line(s), %d characters", len(lines), utf8.RuneCountInString(content))
+		return style.Document.NormalDim.Render(summary)
+	}
+
+	shown := lines[:min(len(lines), m.PreviewLines)]
+	if len(lines) > m.PreviewLines {
+		shown[len(shown)-1] += " …"
+	}
+	if m.Width > 0 {
+		for i, line := range shown {
+			shown[i] = ansi.Truncate(line, m.Width, "…")
+		}
+	}
+
+	return strings.Join(shown, "\n")
 }
 
 func (m *NoteModel) Focus() tea.Cmd {
@@ -113,7 +182,11 @@ func (m *NoteModel) SetValues(vals ...string) error {
 	return nil
 }
 
-func (m *NoteModel) Validate() {}
+func (m *NoteModel) Validate() {
+	if m.ValidateFunc != nil {
+		m.err = m.ValidateFunc(m.content)
+	}
+}
 func (m *NoteModel) Error() string {
 	if m.err != nil {
 		return m.err.Error()
@@ -5,10 +5,19 @@ import (
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
 )
 
 type TextInputWrapper struct {
 	model textinput.Model
+
+	// HintFunc optionally describes the current value, such as the absolute
+	// date resolved from a relative one.
+	HintFunc func(string) string
+
+	// HighlightFunc optionally styles the value, such as the syntax of a
+	// search query. The value is expected to fit the width of the input.
+	HighlightFunc func(string) string
 }
 
 func NewTextInputWrapper(input textinput.Model) *TextInputWrapper {
@@ -40,7 +49,31 @@ func (t *TextInputWrapper) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (t *TextInputWrapper) View() string {
-	return t.model.View()
+	if t.HighlightFunc == nil || t.model.Value() == "" {
+		return t.model.View()
+	}
+
+	value := []rune(t.model.Value())
+	pos := t.model.Position()
+	highlighted := t.HighlightFunc(string(value))
+
+	left := ansi.StringWidth(string(value[:pos]))
+	char, right := " ", left
+	if pos < len(value) {
+		char = string(value[pos])
+		right = left + ansi.StringWidth(char)
+	}
+
+	view := ansi.Cut(highlighted, 0, left)
+	if t.model.Focused() {
+		t.model.Cursor.SetChar(char)
+		view += t.model.Cursor.View()
+	} else {
+		view += ansi.Cut(highlighted, left, right)
+	}
+	view += ansi.Cut(highlighted, right, ansi.StringWidth(highlighted))
+
+	return t.model.PromptStyle.Render(t.model.Prompt) + view
 }
 
 func (t *TextInputWrapper) Value() string {
@@ -73,6 +106,28 @@ func (t *TextInputWrapper) Error() string {
 	return t.model.Err.Error()
 }
 
+// ValidateFunc returns the validation of the input, nil if there is none.
+func (t *TextInputWrapper) ValidateFunc() func(string) error {
+	return t.model.Validate
+}
+
+// Hint returns the hint for the current value, or an empty string when there
+// is no hint or the value is invalid.
+func (t *TextInputWrapper) Hint() string {
+	if t.HintFunc == nil || t.model.Value() == "" {
+		return ""
+	}
+	if t.model.Validate != nil && t.model.Validate(t.model.Value()) != nil {
+		return ""
+	}
+	return t.HintFunc(t.model.Value())
+}
+
+// CursorEnd moves the cursor to the end of the value.
+func (t *TextInputWrapper) CursorEnd() {
+	t.model.CursorEnd()
+}
+
 func (t *TextInputWrapper) Clear() {
 	t.model.SetValue("")
 	t.model.Err = nil
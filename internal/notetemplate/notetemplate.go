@@ -0,0 +1,109 @@
+// Package notetemplate loads the markdown note templates of each record type
+// and renders them with text/template.
+package notetemplate
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/liuminhaw/yatijapp-tui/internal/datetime"
+)
+
+// Template is a markdown note template, named after its file.
+type Template struct {
+	Name string
+	Path string
+}
+
+// Vars are the values available to templates, as {{title}}, {{target}},
+// {{action}}, {{user}}, {{date}} and {{time}}. {{date}} and {{time}} take an
+// optional Go layout, such as {{date "Jan 2"}}.
+type Vars struct {
+	Title  string
+	Target string
+	Action string
+	User   string
+	Now    time.Time
+}
+
+// List returns the templates of the record type, the .md files in the
+// directory named after the type, sorted by name. A missing directory has no
+// templates.
+func List(dir, recordType string) ([]Template, error) {
+	typeDir := filepath.Join(dir, strings.ToLower(recordType))
+
+	entries, err := os.ReadDir(typeDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var templates []Template
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".md" {
+			continue
+		}
+		templates = append(templates, Template{
+			Name: strings.TrimSuffix(entry.Name(), ".md"),
+			Path: filepath.Join(typeDir, entry.Name()),
+		})
+	}
+	slices.SortFunc(templates, func(a, b Template) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return templates, nil
+}
+
+// Render reads the template and renders it with the vars.
+func (t Template) Render(vars Vars) (string, error) {
+	content, err := os.ReadFile(t.Path)
+	if err != nil {
+		return "", err
+	}
+
+	tmpl, err := template.New(t.Name).Funcs(funcs(vars)).Parse(string(content))
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, vars); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func funcs(vars Vars) template.FuncMap {
+	f := datetime.Display()
+	now := vars.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	return template.FuncMap{
+		"title":  func() string { return vars.Title },
+		"target": func() string { return vars.Target },
+		"action": func() string { return vars.Action },
+		"user":   func() string { return vars.User },
+		"date": func(layout ...string) string {
+			if len(layout) > 0 {
+				return f.In(now).Format(layout[0])
+			}
+			return f.Date(now)
+		},
+		"time": func(layout ...string) string {
+			if len(layout) > 0 {
+				return f.In(now).Format(layout[0])
+			}
+			return f.Clock(now)
+		},
+	}
+}
@@ -0,0 +1,102 @@
+// Package progress follows the completion of the actions of a target over
+// time. The API keeps no history of the status changes, so an action counts
+// as completed or canceled from its last update on.
+package progress
+
+import (
+	"time"
+
+	"github.com/liuminhaw/yatijapp-tui/internal/datetime"
+)
+
+// Action is the part of an action the progress is built from.
+type Action struct {
+	Created time.Time
+	Updated time.Time
+	Status  string
+}
+
+// Counts are the number of actions of each status.
+type Counts struct {
+	Total      int
+	Queued     int
+	InProgress int
+	Completed  int
+	Canceled   int
+}
+
+// Count counts the actions of each status.
+func Count(actions []Action) Counts {
+	c := Counts{Total: len(actions)}
+	for _, a := range actions {
+		switch a.Status {
+		case "queued":
+			c.Queued++
+		case "in progress":
+			c.InProgress++
+		case "completed":
+			c.Completed++
+		case "canceled":
+			c.Canceled++
+		}
+	}
+	return c
+}
+
+// Day is the progress at the end of a day. The scope is the actions created
+// so far, leaving out the ones canceled by then.
+type Day struct {
+	Date      time.Time
+	Scope     int
+	Completed int
+}
+
+func (d Day) Remaining() int {
+	return d.Scope - d.Completed
+}
+
+// Days returns the progress of each day from the day the first action was
+// created to the day of now.
+func Days(actions []Action, now time.Time) []Day {
+	if len(actions) == 0 {
+		return nil
+	}
+
+	first := datetime.StartOfDay(now)
+	for _, a := range actions {
+		if created := datetime.StartOfDay(a.Created.In(now.Location())); created.Before(first) {
+			first = created
+		}
+	}
+
+	days := make([]Day, datetime.DaysBetween(first, now)+1)
+	// scope and completed hold the changes of each day until summed up
+	scope := make([]int, len(days))
+	completed := make([]int, len(days))
+	for _, a := range actions {
+		created := datetime.DaysBetween(first, a.Created.In(now.Location()))
+		if created >= len(days) {
+			continue
+		}
+		updated := min(max(datetime.DaysBetween(first, a.Updated.In(now.Location())), created), len(days)-1)
+
+		scope[created]++
+		switch a.Status {
+		case "canceled":
+			scope[updated]--
+		case "completed":
+			completed[updated]++
+		}
+	}
+
+	for i := range days {
+		days[i].Date = first.AddDate(0, 0, i)
+		days[i].Scope = scope[i]
+		days[i].Completed = completed[i]
+		if i > 0 {
+			days[i].Scope += days[i-1].Scope
+			days[i].Completed += days[i-1].Completed
+		}
+	}
+	return days
+}
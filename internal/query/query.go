@@ -0,0 +1,355 @@
+// Package query parses the search query language, such as
+//
+//	status:"in progress" due:<2025-12-31 target:"Website" has:notes sort:-due
+//
+// Free words and quoted phrases are searched for, the terms narrow down the
+// records. The search, status and sort are sent to the API, the other terms
+// are matched client-side.
+package query
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/liuminhaw/yatijapp-tui/internal/datetime"
+)
+
+// Keys are the keys of the query terms.
+var Keys = []string{"status", "due", "target", "has", "sort"}
+
+var statuses = []string{"queued", "in progress", "completed", "canceled"}
+
+var sortKeys = map[string]string{
+	"id":          "serial_id",
+	"serial_id":   "serial_id",
+	"due":         "due_date",
+	"due date":    "due_date",
+	"due_date":    "due_date",
+	"created":     "created_at",
+	"created at":  "created_at",
+	"created_at":  "created_at",
+	"starts":      "starts_at",
+	"starts at":   "starts_at",
+	"starts_at":   "starts_at",
+	"updated":     "updated_at",
+	"updated at":  "updated_at",
+	"updated_at":  "updated_at",
+	"active":      "last_active",
+	"last active": "last_active",
+	"last_active": "last_active",
+}
+
+// TokenKind is the kind of a token of the query.
+type TokenKind int
+
+const (
+	TokenText    TokenKind = iota // searched word or phrase
+	TokenKey                      // term key along with its colon
+	TokenValue                    // term value
+	TokenInvalid                  // term that failed to parse
+)
+
+// Token is a part of the query, Start and End are its byte offsets.
+type Token struct {
+	Kind       TokenKind
+	Start, End int
+}
+
+// Error is a parse error at the byte offset Pos of the query.
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return e.Msg
+}
+
+// DueOp compares the due date of the records.
+type DueOp string
+
+const (
+	DueBefore      DueOp = "<"
+	DueBeforeOrOn  DueOp = "<="
+	DueAfter       DueOp = ">"
+	DueAfterOrOn   DueOp = ">="
+	DueOn          DueOp = "="
+	DueNone        DueOp = "none"
+	DueAny         DueOp = "any"
+	dueUnspecified DueOp = ""
+)
+
+// Query is a parsed search query.
+type Query struct {
+	Text   string
+	Status []string
+	DueOp  DueOp
+	Due    time.Time // day compared by DueOp
+	Target string
+	Has    []string // "notes" or "due"
+	Sort   string   // API sort key, prefixed with "-" when descending
+
+	// Tokens of the query, set even when it fails to parse
+	Tokens []Token
+}
+
+// Fields are the values of a record the query is matched against.
+type Fields struct {
+	Status      string
+	TargetTitle string
+	Due         time.Time
+	HasDue      bool
+	HasNotes    bool
+}
+
+// Parse parses the query, relative dates resolve against now. The returned
+// error is the first *Error of the query.
+func Parse(input string, now time.Time) (Query, error) {
+	var q Query
+	var firstErr error
+	var text []string
+
+	for _, t := range split(input) {
+		if t.err != nil {
+			q.Tokens = append(q.Tokens, Token{Kind: TokenInvalid, Start: t.start, End: t.end})
+			if firstErr == nil {
+				firstErr = t.err
+			}
+			continue
+		}
+
+		if t.key == "" {
+			q.Tokens = append(q.Tokens, Token{Kind: TokenText, Start: t.start, End: t.end})
+			text = append(text, t.value)
+			continue
+		}
+
+		if err := q.apply(t, now); err != nil {
+			q.Tokens = append(q.Tokens, Token{Kind: TokenInvalid, Start: t.start, End: t.end})
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		q.Tokens = append(q.Tokens,
+			Token{Kind: TokenKey, Start: t.start, End: t.valueStart},
+			Token{Kind: TokenValue, Start: t.valueStart, End: t.end},
+		)
+	}
+	q.Text = strings.Join(text, " ")
+
+	return q, firstErr
+}
+
+// QueryStrings returns the query strings of the API request, for the parts of
+// the query the API handles.
+func (q Query) QueryStrings() map[string]string {
+	qs := make(map[string]string)
+	if q.Text != "" {
+		qs["search"] = q.Text
+	}
+	if len(q.Status) > 0 {
+		qs["status"] = strings.Join(q.Status, ",")
+	}
+	if q.Sort != "" {
+		qs["sort"] = q.Sort
+	}
+	return qs
+}
+
+// Empty reports whether the query has neither text nor terms.
+func (q Query) Empty() bool {
+	return q.Text == "" && len(q.Status) == 0 && q.DueOp == dueUnspecified &&
+		q.Target == "" && len(q.Has) == 0 && q.Sort == ""
+}
+
+// Match reports whether a record with the fields satisfies the terms of the
+// query. The status is matched as well, for the API endpoints ignoring it.
+func (q Query) Match(f Fields) bool {
+	if len(q.Status) > 0 && !slices.Contains(q.Status, f.Status) {
+		return false
+	}
+	if q.Target != "" && !strings.Contains(strings.ToLower(f.TargetTitle), strings.ToLower(q.Target)) {
+		return false
+	}
+	for _, has := range q.Has {
+		switch has {
+		case "notes":
+			if !f.HasNotes {
+				return false
+			}
+		case "due":
+			if !f.HasDue {
+				return false
+			}
+		}
+	}
+
+	return q.matchDue(f)
+}
+
+func (q Query) matchDue(f Fields) bool {
+	switch q.DueOp {
+	case dueUnspecified:
+		return true
+	case DueNone:
+		return !f.HasDue
+	case DueAny:
+		return f.HasDue
+	}
+	if !f.HasDue {
+		return false
+	}
+
+	return q.DueRange().Contains(f.Due)
+}
+
+// DueRange returns the days the due date comparison of the query stands
+// for, when comparing to a day.
+func (q Query) DueRange() datetime.DayRange {
+	day := q.Due
+	switch q.DueOp {
+	case DueBefore:
+		return datetime.DayRange{To: day.AddDate(0, 0, -1)}
+	case DueBeforeOrOn:
+		return datetime.DayRange{To: day}
+	case DueAfter:
+		return datetime.DayRange{From: day.AddDate(0, 0, 1)}
+	case DueAfterOrOn:
+		return datetime.DayRange{From: day}
+	case DueOn:
+		return datetime.DayRange{From: day, To: day}
+	default:
+		return datetime.DayRange{}
+	}
+}
+
+func (q *Query) apply(t term, now time.Time) error {
+	fail := func(format string, a ...any) error {
+		return &Error{Pos: t.valueStart, Msg: fmt.Sprintf(format, a...)}
+	}
+
+	value := strings.ToLower(t.value)
+	switch t.key {
+	case "status":
+		for _, s := range strings.Split(value, ",") {
+			s = strings.NewReplacer("-", " ", "_", " ").Replace(strings.TrimSpace(s))
+			if !slices.Contains(statuses, s) {
+				return fail("unknown status %q, expected one of %s", s, strings.Join(statuses, ", "))
+			}
+			if !slices.Contains(q.Status, s) {
+				q.Status = append(q.Status, s)
+			}
+		}
+	case "due":
+		if value == "none" || value == "any" {
+			q.DueOp = DueOp(value)
+			return nil
+		}
+		op := DueOn
+		for _, o := range []DueOp{DueBeforeOrOn, DueAfterOrOn, DueBefore, DueAfter, DueOn} {
+			if strings.HasPrefix(value, string(o)) {
+				op = o
+				value = strings.TrimPrefix(value, string(o))
+				break
+			}
+		}
+		due, err := datetime.ParseDate(value, now)
+		if err != nil {
+			return fail("invalid due date %q", value)
+		}
+		q.DueOp, q.Due = op, due
+	case "target":
+		q.Target = t.value
+	case "has":
+		switch value {
+		case "notes", "note":
+			value = "notes"
+		case "due":
+		default:
+			return fail("unknown has:%s, expected notes or due", value)
+		}
+		if !slices.Contains(q.Has, value) {
+			q.Has = append(q.Has, value)
+		}
+	case "sort":
+		desc := strings.HasPrefix(value, "-")
+		key, ok := sortKeys[strings.TrimPrefix(value, "-")]
+		if !ok {
+			return fail("unknown sort %q", strings.TrimPrefix(value, "-"))
+		}
+		if desc {
+			key = "-" + key
+		}
+		q.Sort = key
+	}
+
+	return nil
+}
+
+// term is a whitespace separated part of the query, key is empty for
+// searched words and phrases.
+type term struct {
+	key        string
+	value      string
+	start, end int
+	valueStart int
+	err        error
+}
+
+// split splits the input into terms, whitespace within quotes is kept.
+func split(input string) []term {
+	var terms []term
+
+	i := 0
+	for i < len(input) {
+		if input[i] == ' ' || input[i] == '\t' {
+			i++
+			continue
+		}
+
+		start := i
+		quote := -1
+		for i < len(input) && (quote >= 0 || (input[i] != ' ' && input[i] != '\t')) {
+			if input[i] == '"' {
+				if quote >= 0 {
+					quote = -1
+				} else {
+					quote = i
+				}
+			}
+			i++
+		}
+		terms = append(terms, parseTerm(input[start:i], start, quote))
+	}
+
+	return terms
+}
+
+func parseTerm(raw string, start, openQuote int) term {
+	t := term{start: start, end: start + len(raw), valueStart: start}
+	if openQuote >= 0 {
+		t.err = &Error{Pos: openQuote, Msg: "unterminated quote"}
+		return t
+	}
+
+	// words with a colon but no known key, like "10:30" or "Meeting:", are
+	// searched for as they are
+	colon := strings.IndexByte(raw, ':')
+	if colon <= 0 || strings.ContainsRune(raw[:colon], '"') ||
+		!slices.Contains(Keys, strings.ToLower(raw[:colon])) {
+		t.value = strings.ReplaceAll(raw, `"`, "")
+		return t
+	}
+
+	t.key = strings.ToLower(raw[:colon])
+	t.valueStart = start + colon + 1
+	t.value = strings.ReplaceAll(raw[colon+1:], `"`, "")
+	if strings.TrimSpace(t.value) == "" {
+		t.err = &Error{Pos: t.valueStart, Msg: "missing value for " + t.key}
+	}
+
+	return t
+}
@@ -0,0 +1,198 @@
+package query
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2025, time.June, 15, 12, 0, 0, 0, time.UTC)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  Query
+		err   string // error message, empty when the query parses
+	}{
+		{
+			name:  "free words",
+			input: "write report",
+			want:  Query{Text: "write report"},
+		},
+		{
+			name:  "quoted phrase",
+			input: `"write report" draft`,
+			want:  Query{Text: "write report draft"},
+		},
+		{
+			name:  "clock is text",
+			input: "standup 10:30",
+			want:  Query{Text: "standup 10:30"},
+		},
+		{
+			name:  "unknown key is text",
+			input: "Meeting: planning",
+			want:  Query{Text: "Meeting: planning"},
+		},
+		{
+			name:  "url is text",
+			input: "https://example.com",
+			want:  Query{Text: "https://example.com"},
+		},
+		{
+			name:  "statuses",
+			input: `status:queued,in-progress status:"completed"`,
+			want:  Query{Status: []string{"queued", "in progress", "completed"}},
+		},
+		{
+			name:  "unknown status",
+			input: "status:done",
+			err:   `unknown status "done", expected one of queued, in progress, completed, canceled`,
+		},
+		{
+			name:  "due before",
+			input: "due:<2025-06-20",
+			want:  Query{DueOp: DueBefore, Due: day(2025, time.June, 20)},
+		},
+		{
+			name:  "due on relative day",
+			input: "due:tomorrow",
+			want:  Query{DueOp: DueOn, Due: day(2025, time.June, 16)},
+		},
+		{
+			name:  "due none",
+			input: "due:none",
+			want:  Query{DueOp: DueNone},
+		},
+		{
+			name:  "invalid due",
+			input: "due:<someday",
+			err:   `invalid due date "someday"`,
+		},
+		{
+			name:  "missing value",
+			input: "target:",
+			err:   "missing value for target",
+		},
+		{
+			name:  "target and has",
+			input: `Target:"Web site" has:note has:due report`,
+			want:  Query{Text: "report", Target: "Web site", Has: []string{"notes", "due"}},
+		},
+		{
+			name:  "unknown has",
+			input: "has:sessions",
+			err:   "unknown has:sessions, expected notes or due",
+		},
+		{
+			name:  "sort descending",
+			input: "sort:-due",
+			want:  Query{Sort: "-due_date"},
+		},
+		{
+			name:  "unknown sort",
+			input: "sort:-size",
+			err:   `unknown sort "size"`,
+		},
+		{
+			name:  "unterminated quote",
+			input: `target:"Web site`,
+			err:   "unterminated quote",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input, now)
+			if tt.err != "" {
+				var qe *Error
+				if !errors.As(err, &qe) || qe.Msg != tt.err {
+					t.Fatalf("Parse(%q) error = %v, want %q", tt.input, err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.input, err)
+			}
+
+			got.Tokens = nil
+			if got.Text != tt.want.Text ||
+				!slices.Equal(got.Status, tt.want.Status) ||
+				got.DueOp != tt.want.DueOp ||
+				!got.Due.Equal(tt.want.Due) ||
+				got.Target != tt.want.Target ||
+				!slices.Equal(got.Has, tt.want.Has) ||
+				got.Sort != tt.want.Sort {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryStrings(t *testing.T) {
+	tests := []struct {
+		input string
+		want  map[string]string
+	}{
+		{input: "", want: map[string]string{}},
+		{input: "report", want: map[string]string{"search": "report"}},
+		{
+			input: "status:queued,canceled sort:created report",
+			want:  map[string]string{"search": "report", "status": "queued,canceled", "sort": "created_at"},
+		},
+		{input: "due:today has:notes target:web", want: map[string]string{}},
+	}
+
+	for _, tt := range tests {
+		q, err := Parse(tt.input, now)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", tt.input, err)
+		}
+		if got := q.QueryStrings(); !maps.Equal(got, tt.want) {
+			t.Errorf("QueryStrings() of %q = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestMatchDue(t *testing.T) {
+	due := func(d time.Time) Fields { return Fields{Due: d, HasDue: true} }
+	noDue := Fields{}
+
+	tests := []struct {
+		input  string
+		fields Fields
+		want   bool
+	}{
+		{input: "report", fields: noDue, want: true},
+		{input: "due:none", fields: noDue, want: true},
+		{input: "due:none", fields: due(day(2025, time.June, 15)), want: false},
+		{input: "due:any", fields: noDue, want: false},
+		{input: "due:any", fields: due(day(2025, time.June, 15)), want: true},
+		{input: "due:2025-06-20", fields: noDue, want: false},
+		{input: "due:2025-06-20", fields: due(time.Date(2025, time.June, 20, 18, 30, 0, 0, time.UTC)), want: true},
+		{input: "due:2025-06-20", fields: due(day(2025, time.June, 21)), want: false},
+		{input: "due:<2025-06-20", fields: due(day(2025, time.June, 19)), want: true},
+		{input: "due:<2025-06-20", fields: due(day(2025, time.June, 20)), want: false},
+		{input: "due:<=2025-06-20", fields: due(day(2025, time.June, 20)), want: true},
+		{input: "due:>2025-06-20", fields: due(day(2025, time.June, 20)), want: false},
+		{input: "due:>2025-06-20", fields: due(day(2025, time.June, 21)), want: true},
+		{input: "due:>=2025-06-20", fields: due(day(2025, time.June, 20)), want: true},
+	}
+
+	for _, tt := range tests {
+		q, err := Parse(tt.input, now)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", tt.input, err)
+		}
+		if got := q.matchDue(tt.fields); got != tt.want {
+			t.Errorf("matchDue() of %q with %+v = %v, want %v", tt.input, tt.fields, got, tt.want)
+		}
+	}
+}
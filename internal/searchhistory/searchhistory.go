@@ -0,0 +1,93 @@
+// Package searchhistory keeps the recent search queries of each profile, a
+// profile being a user signed in to an API endpoint. The queries of a
+// profile are kept in one JSON file.
+package searchhistory
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// MaxQueries is the number of queries kept for each profile, older queries
+// are dropped first.
+const MaxQueries = 100
+
+// Store keeps the search queries in files under Dir.
+type Store struct {
+	Dir string
+}
+
+var mu sync.Mutex
+
+func (s Store) path(profile string) (string, error) {
+	if profile == "" || strings.ContainsAny(profile, `/\.`) {
+		return "", errors.New("invalid search history profile: " + profile)
+	}
+	return filepath.Join(s.Dir, profile+".json"), nil
+}
+
+// Queries returns the queries of the profile, most recent first.
+func (s Store) Queries(profile string) ([]string, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return s.queries(profile)
+}
+
+func (s Store) queries(profile string) ([]string, error) {
+	path, err := s.path(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var queries []string
+	if err := json.Unmarshal(content, &queries); err != nil {
+		return nil, err
+	}
+	return queries, nil
+}
+
+// Add records the query as the most recent one of the profile, dropping its
+// earlier occurrence.
+func (s Store) Add(profile, query string) error {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	queries, err := s.queries(profile)
+	if err != nil {
+		return err
+	}
+	queries = slices.DeleteFunc(queries, func(q string) bool { return q == query })
+	queries = slices.Insert(queries, 0, query)
+	if len(queries) > MaxQueries {
+		queries = queries[:MaxQueries]
+	}
+
+	content, err := json.Marshal(queries)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.Dir, 0o700); err != nil {
+		return err
+	}
+	path, _ := s.path(profile)
+	return os.WriteFile(path, content, 0o600)
+}
@@ -0,0 +1,78 @@
+package stats
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Snapshot is the intervals fetched at FetchedAt.
+type Snapshot struct {
+	FetchedAt time.Time  `json:"fetched_at"`
+	Intervals []Interval `json:"intervals"`
+}
+
+// Cache keeps the latest snapshot of each profile in a file under Dir, so
+// the statistics show up before the sessions are fetched again.
+type Cache struct {
+	Dir string
+}
+
+var mu sync.Mutex
+
+func (c Cache) path(profile string) (string, error) {
+	if profile == "" || strings.ContainsAny(profile, `/\.`) {
+		return "", errors.New("invalid stats cache profile: " + profile)
+	}
+	return filepath.Join(c.Dir, profile+".json"), nil
+}
+
+// Load returns the snapshot of the profile, a zero snapshot if none is
+// cached yet.
+func (c Cache) Load(profile string) (Snapshot, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	path, err := c.path(profile)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Snapshot{}, nil
+	} else if err != nil {
+		return Snapshot{}, err
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(content, &snapshot); err != nil {
+		return Snapshot{}, err
+	}
+	return snapshot, nil
+}
+
+// Save replaces the snapshot of the profile.
+func (c Cache) Save(profile string, snapshot Snapshot) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	path, err := c.path(profile)
+	if err != nil {
+		return err
+	}
+
+	content, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.Dir, 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0o600)
+}
@@ -0,0 +1,171 @@
+// Package stats computes statistics of the tracked time out of the session
+// intervals: the time per day of the last year, the streaks of days with
+// tracked time, and the time per weekday and per hour of the day.
+package stats
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/liuminhaw/yatijapp-tui/internal/datetime"
+)
+
+// Weeks is the number of weeks of the heatmap, the current one included.
+const Weeks = 53
+
+// Interval is the time span of a session, open sessions end at the time
+// they are fetched.
+type Interval struct {
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+	TargetUUID  string    `json:"target_uuid"`
+	TargetTitle string    `json:"target_title"`
+}
+
+// Stats are the statistics of the tracked time over the weeks of the
+// heatmap.
+type Stats struct {
+	// From is the monday the heatmap starts on, Days holds the tracked time
+	// of each day from From to today.
+	From    time.Time
+	Days    []time.Duration
+	Busiest time.Duration // tracked time of the busiest day
+
+	Total         time.Duration
+	CurrentStreak int
+	LongestStreak int
+
+	Weekdays [7]time.Duration // indexed by time.Weekday
+	Hours    [24]time.Duration
+}
+
+// Compute computes the statistics of the intervals of the target, of all
+// the intervals if target is empty, over the weeks before now.
+func Compute(intervals []Interval, target string, now time.Time) Stats {
+	today := datetime.StartOfDay(now)
+	s := Stats{From: Start(now)}
+	s.Days = make([]time.Duration, datetime.DaysBetween(s.From, today)+1)
+	end := today.AddDate(0, 0, 1)
+
+	for _, interval := range intervals {
+		if target != "" && interval.TargetUUID != target {
+			continue
+		}
+
+		t := later(interval.Start.In(now.Location()), s.From)
+		until := earlier(interval.End.In(now.Location()), end)
+		for t.Before(until) {
+			next := earlier(
+				time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location()),
+				until,
+			)
+			if !next.After(t) {
+				next = until
+			}
+			d := next.Sub(t)
+			s.Days[datetime.DaysBetween(s.From, t)] += d
+			s.Weekdays[t.Weekday()] += d
+			s.Hours[t.Hour()] += d
+			s.Total += d
+			t = next
+		}
+	}
+
+	s.Busiest = slices.Max(s.Days)
+	s.streaks()
+	return s
+}
+
+// Start returns the monday the heatmap of the weeks before now starts on.
+func Start(now time.Time) time.Time {
+	return datetime.Week(now).From.AddDate(0, 0, -7*(Weeks-1))
+}
+
+// streaks counts the days in a row with tracked time. The current streak
+// still holds today before any time is tracked.
+func (s *Stats) streaks() {
+	run := 0
+	for _, d := range s.Days {
+		if d > 0 {
+			run++
+			s.LongestStreak = max(s.LongestStreak, run)
+		} else {
+			run = 0
+		}
+	}
+
+	days := s.Days
+	if len(days) > 0 && days[len(days)-1] == 0 {
+		days = days[:len(days)-1]
+	}
+	for i := len(days) - 1; i >= 0 && days[i] > 0; i-- {
+		s.CurrentStreak++
+	}
+}
+
+// Day returns the date of the day at index i of Days.
+func (s Stats) Day(i int) time.Time {
+	return s.From.AddDate(0, 0, i)
+}
+
+// BusiestWeekday returns the weekday with the most tracked time.
+func (s Stats) BusiestWeekday() (time.Weekday, time.Duration) {
+	busiest := time.Monday
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		if s.Weekdays[wd] > s.Weekdays[busiest] {
+			busiest = wd
+		}
+	}
+	return busiest, s.Weekdays[busiest]
+}
+
+// Level grades the tracked time of a day from 0, nothing tracked, to 4
+// compared to the busiest day.
+func (s Stats) Level(d time.Duration) int {
+	if d <= 0 || s.Busiest <= 0 {
+		return 0
+	}
+	return min(4, int((4*d+s.Busiest-1)/s.Busiest))
+}
+
+// Target is a target of the intervals with its tracked time.
+type Target struct {
+	UUID  string
+	Title string
+	Total time.Duration
+}
+
+// Targets returns the targets of the intervals, most tracked first.
+func Targets(intervals []Interval) []Target {
+	index := make(map[string]int)
+	var targets []Target
+	for _, interval := range intervals {
+		i, ok := index[interval.TargetUUID]
+		if !ok {
+			i = len(targets)
+			index[interval.TargetUUID] = i
+			targets = append(targets, Target{UUID: interval.TargetUUID, Title: interval.TargetTitle})
+		}
+		targets[i].Total += interval.End.Sub(interval.Start)
+	}
+
+	slices.SortStableFunc(targets, func(a, b Target) int {
+		return cmp.Compare(b.Total, a.Total)
+	})
+	return targets
+}
+
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earlier(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
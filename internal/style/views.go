@@ -125,6 +125,18 @@ func FullHelpView(contents []FullHelpContent, width int) string {
 	return BorderStyle["highlighted"].Width(width).Padding(0, 1).Render(b.String())
 }
 
+// ProgressBarView returns a bar of the given width filled in proportion to
+// done out of total.
+func ProgressBarView(done, total int64, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = int(min(max(done, 0), total) * int64(width) / total)
+	}
+
+	return lipgloss.NewStyle().Foreground(colors.Success).Render(strings.Repeat("━", filled)) +
+		lipgloss.NewStyle().Foreground(colors.BgMuted).Render(strings.Repeat("━", width-filled))
+}
+
 func MsgView(sizing ViewSize, msg string) string {
 	return MsgStyle.Width(sizing.Width).
 		Height(sizing.Height).
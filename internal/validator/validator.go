@@ -7,6 +7,7 @@ import (
 	"time"
 	"unicode/utf8"
 
+	"github.com/liuminhaw/yatijapp-tui/internal/datetime"
 	"golang.org/x/text/unicode/norm"
 )
 
@@ -21,45 +22,44 @@ func MultipleValidators(validators ...func(string) error) func(string) error {
 	}
 }
 
-var (
-	ValidDateFormats     = []string{"2006-01-02"}
-	ValidDateTimeFormats = []string{"2006-01-02 15:04:05"}
-)
+// ValidateDateTime accepts the absolute and natural-language date formats
+// understood by datetime.Parse.
+func ValidateDateTime() func(string) error {
+	return func(date string) error {
+		if date == "" {
+			return nil
+		}
 
-func ValidateDateTime(formats []string) func(string) error {
+		if _, err := datetime.Parse(date, datetime.Now()); err != nil {
+			return errors.New("invalid format")
+		}
+		return nil
+	}
+}
+
+// ValidateDate accepts the absolute and natural-language dates understood by
+// datetime.ParseDate, without a time of day.
+func ValidateDate() func(string) error {
 	return func(date string) error {
 		if date == "" {
 			return nil
 		}
 
-		for _, f := range formats {
-			if _, err := time.ParseInLocation(f, date, time.Local); err == nil {
-				return nil
-			}
+		if _, err := datetime.ParseDate(date, datetime.Now()); err != nil {
+			return errors.New("invalid format")
 		}
-		return errors.New("invalid format")
+		return nil
 	}
 }
 
 func ValidateDateAfter(date time.Time) func(string) error {
-	validFormats := []string{"2006-01-02"}
-
 	return func(input string) error {
 		if input == "" {
 			return nil
 		}
 
-		var parsedDate time.Time
-		var err error
-		parsed := false
-		for _, f := range validFormats {
-			parsedDate, err = time.ParseInLocation(f, input, time.Local)
-			if err == nil {
-				parsed = true
-				break
-			}
-		}
-		if !parsed {
+		parsedDate, err := datetime.ParseDate(input, datetime.Now())
+		if err != nil {
 			return errors.New("invalid date format")
 		}
 
@@ -70,6 +70,20 @@ func ValidateDateAfter(date time.Time) func(string) error {
 	}
 }
 
+// ValidateDuration accepts the durations understood by datetime.ParseDuration.
+func ValidateDuration() func(string) error {
+	return func(input string) error {
+		if input == "" {
+			return nil
+		}
+
+		if _, err := datetime.ParseDuration(input); err != nil {
+			return errors.New("invalid duration")
+		}
+		return nil
+	}
+}
+
 func ValidateRequired(msg string) func(string) error {
 	return func(input string) error {
 		if input == "" {
@@ -0,0 +1,139 @@
+// Package braille draws line charts in the terminal with braille patterns,
+// each character cell holding a grid of 2x4 dots.
+package braille
+
+import "strings"
+
+// dotBits are the bits of the braille pattern for each dot of a cell,
+// indexed by column then by row from the top.
+var dotBits = [2][4]rune{
+	{0x01, 0x02, 0x04, 0x40},
+	{0x08, 0x10, 0x20, 0x80},
+}
+
+type cell struct {
+	dots   rune
+	series int // last series drawn in the cell, -1 when empty
+}
+
+// Canvas is a grid of Width x Height character cells. Dots are addressed
+// from the bottom left corner, x up to 2*Width and y up to 4*Height.
+type Canvas struct {
+	Width  int
+	Height int
+	cells  []cell
+}
+
+func New(width, height int) *Canvas {
+	c := &Canvas{Width: width, Height: height, cells: make([]cell, width*height)}
+	for i := range c.cells {
+		c.cells[i].series = -1
+	}
+	return c
+}
+
+// Set turns on the dot at x, y for the series. Dots off the canvas are
+// ignored.
+func (c *Canvas) Set(x, y, series int) {
+	if x < 0 || y < 0 || x >= 2*c.Width || y >= 4*c.Height {
+		return
+	}
+	row := 4*c.Height - 1 - y
+	cl := &c.cells[(row/4)*c.Width+x/2]
+	cl.dots |= dotBits[x%2][row%4]
+	cl.series = series
+}
+
+// Line draws a straight line between two dots.
+func (c *Canvas) Line(x0, y0, x1, y1, series int) {
+	dx, sx := abs(x1-x0), sign(x1-x0)
+	dy, sy := -abs(y1-y0), sign(y1-y0)
+	err := dx + dy
+	for {
+		c.Set(x0, y0, series)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+// Plot draws the values as a line spread over the width of the canvas,
+// scaled so that top reaches the top row.
+func (c *Canvas) Plot(values []float64, top float64, series int) {
+	if len(values) == 0 || c.Width == 0 || c.Height == 0 {
+		return
+	}
+
+	maxX, maxY := 2*c.Width-1, 4*c.Height-1
+	y := func(v float64) int {
+		if top <= 0 {
+			return 0
+		}
+		return int(min(max(v/top, 0), 1)*float64(maxY) + 0.5)
+	}
+
+	if len(values) == 1 {
+		c.Line(0, y(values[0]), maxX, y(values[0]), series)
+		return
+	}
+
+	px, py := 0, y(values[0])
+	for i := 1; i < len(values); i++ {
+		x := i * maxX / (len(values) - 1)
+		c.Line(px, py, x, y(values[i]), series)
+		px, py = x, y(values[i])
+	}
+}
+
+// Lines returns the rows of the canvas, top first. Each run of cells of the
+// same series is passed to render, with series -1 for empty cells.
+func (c *Canvas) Lines(render func(s string, series int) string) []string {
+	lines := make([]string, c.Height)
+	for row := range c.Height {
+		var line, run strings.Builder
+		current := -1
+		for _, cl := range c.cells[row*c.Width : (row+1)*c.Width] {
+			if cl.series != current && run.Len() > 0 {
+				line.WriteString(render(run.String(), current))
+				run.Reset()
+			}
+			current = cl.series
+			if cl.dots == 0 {
+				run.WriteRune(' ')
+			} else {
+				run.WriteRune(0x2800 + cl.dots)
+			}
+		}
+		if run.Len() > 0 {
+			line.WriteString(render(run.String(), current))
+		}
+		lines[row] = line.String()
+	}
+	return lines
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func sign(n int) int {
+	switch {
+	case n > 0:
+		return 1
+	case n < 0:
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,174 @@
+// Package linediff computes line based diffs of texts and groups them into
+// unified diff hunks.
+package linediff
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Op int
+
+const (
+	Equal Op = iota
+	Insert
+	Delete
+)
+
+// Line is a line of a diff, with the operation turning a into b.
+type Line struct {
+	Op   Op
+	Text string
+}
+
+func (l Line) String() string {
+	switch l.Op {
+	case Insert:
+		return "+" + l.Text
+	case Delete:
+		return "-" + l.Text
+	default:
+		return " " + l.Text
+	}
+}
+
+// Hunk is a group of changed lines along with their context. Starts are 1
+// based line numbers, as in unified diff headers.
+type Hunk struct {
+	AStart, ALen int
+	BStart, BLen int
+	Lines        []Line
+}
+
+// Header formats the hunk header, empty ranges start at the line before them
+// as in unified diffs.
+func (h Hunk) Header() string {
+	aStart, bStart := h.AStart, h.BStart
+	if h.ALen == 0 {
+		aStart--
+	}
+	if h.BLen == 0 {
+		bStart--
+	}
+	return fmt.Sprintf("@@ -%d,%d +%d,%d @@", aStart, h.ALen, bStart, h.BLen)
+}
+
+// Diff returns the lines of a and b aligned along their longest common
+// subsequence, deleted lines come before inserted ones.
+func Diff(a, b []string) []Line {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	lines := make([]Line, 0, max(len(a), len(b)))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, Line{Op: Equal, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, Line{Op: Delete, Text: a[i]})
+			i++
+		default:
+			lines = append(lines, Line{Op: Insert, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, Line{Op: Delete, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, Line{Op: Insert, Text: b[j]})
+	}
+
+	return lines
+}
+
+// Unified diffs the lines of a and b, grouping the changes into hunks with
+// context unchanged lines around them. No hunks are returned for equal texts.
+func Unified(a, b string, context int) []Hunk {
+	lines := Diff(splitLines(a), splitLines(b))
+
+	var hunks []Hunk
+	var hunk *Hunk
+	aLine, bLine := 1, 1
+	lastChange := -1
+	for i, line := range lines {
+		changed := line.Op != Equal
+		if changed {
+			if hunk == nil || i-lastChange > 2*context+1 {
+				if hunk != nil {
+					hunks = append(hunks, trimContext(*hunk, context))
+				}
+				start := max(i-context, 0)
+				hunk = &Hunk{AStart: aLine, BStart: bLine}
+				for _, prev := range lines[start:i] {
+					hunk.Lines = append(hunk.Lines, prev)
+					hunk.AStart--
+					hunk.BStart--
+				}
+				hunk.ALen, hunk.BLen = i-start, i-start
+			}
+			lastChange = i
+		}
+
+		if hunk != nil && (changed || i-lastChange <= 2*context) {
+			hunk.Lines = append(hunk.Lines, line)
+			if line.Op != Insert {
+				hunk.ALen++
+			}
+			if line.Op != Delete {
+				hunk.BLen++
+			}
+		}
+
+		if line.Op != Insert {
+			aLine++
+		}
+		if line.Op != Delete {
+			bLine++
+		}
+	}
+	if hunk != nil {
+		hunks = append(hunks, trimContext(*hunk, context))
+	}
+
+	return hunks
+}
+
+// trimContext drops the unchanged lines after the last change of the hunk
+// beyond the context.
+func trimContext(h Hunk, context int) Hunk {
+	trailing := 0
+	for i := len(h.Lines) - 1; i >= 0 && h.Lines[i].Op == Equal; i-- {
+		trailing++
+	}
+
+	if extra := trailing - context; extra > 0 {
+		h.Lines = h.Lines[:len(h.Lines)-extra]
+		h.ALen -= extra
+		h.BLen -= extra
+	}
+
+	return h
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
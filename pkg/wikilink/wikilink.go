@@ -0,0 +1,84 @@
+// Package wikilink finds wiki style links to other records in markdown, such
+// as [[action:Write migration]], [[target:<uuid>]] or [[<uuid>]].
+package wikilink
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	linkRX = regexp.MustCompile(`\[\[(?:([A-Za-z]+):)?([^\[\]\n]+?)\]\]`)
+	uuidRX = regexp.MustCompile(
+		`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`,
+	)
+)
+
+// Kinds are the record kinds a link can name.
+var Kinds = []string{"target", "action", "session"}
+
+// Link is a link found in a text, Start and End are its byte offsets.
+type Link struct {
+	Raw  string
+	Kind string // lower case record kind, empty for untyped uuid links
+	Ref  string // record title or uuid
+
+	Start, End int
+}
+
+// ByUUID reports whether the link refers to its record by uuid.
+func (l Link) ByUUID() bool {
+	return IsUUID(l.Ref)
+}
+
+// Parse returns the links of the text in order. Links with an unknown kind,
+// and untyped links not referring to a uuid, are skipped.
+func Parse(text string) []Link {
+	var links []Link
+	for _, m := range linkRX.FindAllStringSubmatchIndex(text, -1) {
+		link := Link{
+			Raw:   text[m[0]:m[1]],
+			Ref:   strings.TrimSpace(text[m[4]:m[5]]),
+			Start: m[0],
+			End:   m[1],
+		}
+		if m[2] >= 0 {
+			link.Kind = strings.ToLower(text[m[2]:m[3]])
+			if !slices.Contains(Kinds, link.Kind) {
+				continue
+			}
+		}
+		if link.Ref == "" || (link.Kind == "" && !link.ByUUID()) {
+			continue
+		}
+
+		links = append(links, link)
+	}
+
+	return links
+}
+
+// Replace replaces every link of the text with the result of repl.
+func Replace(text string, repl func(Link) string) string {
+	links := Parse(text)
+	if len(links) == 0 {
+		return text
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, link := range links {
+		b.WriteString(text[last:link.Start])
+		b.WriteString(repl(link))
+		last = link.End
+	}
+	b.WriteString(text[last:])
+
+	return b.String()
+}
+
+// IsUUID reports whether s is a uuid.
+func IsUUID(s string) bool {
+	return uuidRX.MatchString(s)
+}